@@ -0,0 +1,107 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AddFilterAny adds a filter that compares the column with the rows of a subquery or the elements of an array,
+// and matches when any of them satisfies the operator, such as Amount = ANY (SELECT Amount FROM Quotas WHERE ...).
+// The value is a SELECT builder of one column, whose placeholders continue those of the builder, or an array:
+//
+//	sub := New(WithTableName("Quotas"), WithDialect(POSTGRES))
+//	sub.AddColumn("Amount").AddFilter("Region", "EU")
+//	q.AddFilterAny("Amount", OpGte, sub)               // Amount >= ANY (SELECT Amount FROM Quotas WHERE Region = $2)
+//	q.AddFilterAny("Status", OpEq, []string{"A", "B"}) // Status = ANY($3)
+//
+// The builder of a subquery is copied, so later changes to it do not affect the filter. The operator is one of
+// =, <>, >, >=, < and <=, otherwise ErrInvalidOperator is returned by Build. Subqueries are not supported on
+// SQLite and BigQuery, and arrays only on PostgreSQL and DuckDB; Build returns ErrNotSupported for them.
+func (qb *QueryBuilder) AddFilterAny(column string, op Operator, value interface{}) *QueryBuilder {
+	return qb.addQuantified("ANY", column, op, value)
+}
+
+// AddFilterAll adds a filter that compares the column with the rows of a subquery or the elements of an array,
+// and matches when all of them satisfy the operator, such as Amount > ALL (SELECT Amount FROM Quotas WHERE ...).
+// The values are those of AddFilterAny.
+func (qb *QueryBuilder) AddFilterAll(column string, op Operator, value interface{}) *QueryBuilder {
+	return qb.addQuantified("ALL", column, op, value)
+}
+
+// addQuantified adds an ANY or ALL filter
+func (qb *QueryBuilder) addQuantified(quantifier, column string, op Operator, value interface{}) *QueryBuilder {
+	qb.touch()
+	if !comparison(op) {
+		qb.err = joinErrors(qb.err, fmt.Errorf("%w: %q %s on %s", ErrInvalidOperator, op, quantifier, column))
+		return qb
+	}
+	f := queryFilter{expression: column, op: op, quantifier: quantifier}
+	switch t := value.(type) {
+	case nil:
+		qb.err = joinErrors(qb.err, fmt.Errorf("%w: %s of a nil value on %s", ErrInvalidOption, quantifier, column))
+		return qb
+	case *QueryBuilder:
+		if t == nil || t.CommandType != SELECT {
+			qb.err = joinErrors(qb.err, fmt.Errorf("%w: %s of a subquery that is not a SELECT command on %s", ErrInvalidOption, quantifier, column))
+			return qb
+		}
+		f.sub = t.Snapshot().qb
+	default:
+		f.value = value
+	}
+	qb.Filter = append(qb.Filter, f)
+	return qb
+}
+
+// comparison reports whether the operator compares single values
+func comparison(op Operator) bool {
+	switch op {
+	case OpEq, OpNe, OpGt, OpGte, OpLt, OpLte:
+		return true
+	}
+	return false
+}
+
+// buildSubqueries builds the subqueries of the ANY and ALL filters with ? marks, which are bound when they are rendered,
+// and returns the error of the quantified filters that the dialect does not have. The values must be resolved.
+func (qb *QueryBuilder) buildSubqueries(ctx context.Context) error {
+	for i, f := range qb.Filter {
+		if f.quantifier == "" {
+			continue
+		}
+		if f.sub == nil {
+			if !isArray(f.value) {
+				return fmt.Errorf("%w: %s of %T on %s", ErrInvalidOption, f.quantifier, f.value, f.expression)
+			}
+			if qb.Dialect != POSTGRES && qb.Dialect != DUCKDB {
+				return fmt.Errorf("%w: %s of an array on %s", ErrNotSupported, f.quantifier, qb.Dialect)
+			}
+			continue
+		}
+		if qb.Dialect == SQLITE || qb.Dialect == BIGQUERY {
+			return fmt.Errorf("%w: %s of a subquery on %s", ErrNotSupported, f.quantifier, qb.Dialect)
+		}
+		s := *f.sub
+		s.ParameterChar, s.ParameterInSequence, s.ParameterOffset = "?", false, 0
+		s.tags, s.tagsFunc, s.commentFunc = nil, nil, nil
+		query, args, err := s.build(ctx)
+		if err != nil {
+			return err
+		}
+		qb.Filter[i].subSQL = strings.TrimSuffix(strings.TrimSpace(query), ";")
+		qb.Filter[i].subArgs = args
+	}
+	return nil
+}
+
+// quantifiedClause renders an ANY or ALL filter
+func (qb *QueryBuilder) quantifiedClause(f queryFilter, paramcnt, phcnt *int) string {
+	if f.sub != nil {
+		sub, n := qb.bindMarks(f.subSQL, paramcnt)
+		*phcnt += n
+		return f.expression + " " + string(f.op) + " " + f.quantifier + " (" + sub + ")"
+	}
+	*phcnt++
+	return f.expression + " " + string(f.op) + " " + f.quantifier + "(" + qb.placeholder(paramcnt) + ")"
+}
@@ -0,0 +1,85 @@
+package querybuilder
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAddFilterAnyAll(t *testing.T) {
+	sub := New(WithTableName("Quotas"), WithDialect(POSTGRES))
+	sub.AddColumn("Amount")
+	sub.AddFilter("Region", "EU")
+
+	q := New(WithTableName("Orders"), WithDialect(POSTGRES))
+	q.AddColumn("OrderKey")
+	q.AddFilter("Status", "A")
+	q.AddFilterAll("Amount", OpGt, sub)
+	q.AddFilterAny("Channel", OpEq, []string{"WEB", "APP"})
+	q.AddFilter("Active", true)
+	sub.AddFilter("Year", 2024)
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT OrderKey FROM Orders WHERE Status = $1 AND Amount > ALL (SELECT Amount FROM Quotas WHERE Region = $2) " +
+		"AND Channel = ANY($3) AND Active = $4;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"A", "EU", []string{"WEB", "APP"}, true}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	q = New(WithTableName("Orders"), WithDialect(SQLSERVER))
+	q.AddColumn("OrderKey")
+	q.AddFilterAny("Amount", OpGte, sub)
+	s, v, err = q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT OrderKey FROM Orders WHERE Amount >= ANY (SELECT Amount FROM Quotas WHERE Region = @p1 AND Year = @p2);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"EU", 2024}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
+
+func TestAddFilterAnyAllErrors(t *testing.T) {
+	sub := New(WithTableName("Quotas"))
+	sub.AddColumn("Amount")
+
+	tests := []struct {
+		name    string
+		dialect Dialect
+		add     func(q *QueryBuilder)
+		want    error
+	}{
+		{"operator", POSTGRES, func(q *QueryBuilder) { q.AddFilterAny("Amount", OpLike, sub) }, ErrInvalidOperator},
+		{"nil", POSTGRES, func(q *QueryBuilder) { q.AddFilterAll("Amount", OpGt, nil) }, ErrInvalidOption},
+		{"scalar", POSTGRES, func(q *QueryBuilder) { q.AddFilterAny("Amount", OpEq, 5) }, ErrInvalidOption},
+		{"array", MYSQL, func(q *QueryBuilder) { q.AddFilterAny("Amount", OpEq, []int{1, 2}) }, ErrNotSupported},
+		{"subquery", SQLITE, func(q *QueryBuilder) { q.AddFilterAll("Amount", OpGt, sub) }, ErrNotSupported},
+		{"command", POSTGRES, func(q *QueryBuilder) {
+			q.AddFilterAny("Amount", OpEq, New(WithTableName("Quotas"), WithCommand(DELETE)))
+		}, ErrInvalidOption},
+	}
+	for _, tt := range tests {
+		q := New(WithTableName("Orders"), WithDialect(tt.dialect))
+		q.AddColumn("OrderKey")
+		tt.add(q)
+		if _, _, err := q.Build(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	q := New(WithTableName("Orders"))
+	q.AddColumn("OrderKey")
+	q.AddFilterAll("Amount", OpGt, sub)
+	if _, err := json.Marshal(q); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("got %v, want %v", err, ErrNotSupported)
+	}
+}
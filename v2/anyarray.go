@@ -0,0 +1,48 @@
+package querybuilder
+
+import "reflect"
+
+// WithArrayIn sets the condition to bind the values of the IN filters of PostgreSQL as a single array parameter,
+// rendered as UserKey = ANY($1) instead of UserKey IN ($1, $2, $3). The query then stays the same for any
+// number of values, so that the plans of prepared statements are reused. The values are bound as a slice
+// of their type, such as []int64, or as []interface{} when their types differ, and the NULL values are left out.
+//
+// It applies to the filters added by AddFilterIn and by Where with OpIn. Case-insensitive filters, the IN
+// conditions of condition trees and the other dialects keep a placeholder per value.
+func WithArrayIn(enabled bool) Option {
+	return func(q *QueryBuilder) error {
+		q.ArrayIn = enabled
+		return nil
+	}
+}
+
+// arrayIn reports whether the values of an IN filter are bound as an array
+func (qb *QueryBuilder) arrayIn(f queryFilter) bool {
+	return qb.ArrayIn && qb.Dialect == POSTGRES && f.in && !f.ci && len(f.values) > 0
+}
+
+// inArray returns the values that are not nil as a slice of their type, or as a []interface{} when their types differ
+func inArray(values []interface{}) interface{} {
+	var typ reflect.Type
+	vals := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		if isNil(v) {
+			continue
+		}
+		switch t := reflect.TypeOf(v); {
+		case len(vals) == 0:
+			typ = t
+		case t != typ:
+			typ = nil
+		}
+		vals = append(vals, v)
+	}
+	if typ == nil {
+		return vals
+	}
+	arr := reflect.MakeSlice(reflect.SliceOf(typ), len(vals), len(vals))
+	for i, v := range vals {
+		arr.Index(i).Set(reflect.ValueOf(v))
+	}
+	return arr.Interface()
+}
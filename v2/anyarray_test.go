@@ -0,0 +1,36 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArrayIn(t *testing.T) {
+	q := New(WithTableName("Users"), WithDialect(POSTGRES), WithArrayIn(true))
+	q.AddColumn("UserName")
+	q.AddFilter("Active", true)
+	q.AddFilterIn("UserKey", int64(1), nil, int64(3))
+	q.Where(In("Status", "A", 2))
+	q.AddFilterIn("GroupKey")
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users WHERE Active = $1 AND UserKey = ANY($2) AND Status = ANY($3) AND 1 = 0;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	want := []interface{}{true, []int64{1, 3}, []interface{}{"A", 2}}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("got %#v, want %#v", v, want)
+	}
+
+	q = New(WithTableName("Users"), WithDialect(MYSQL), WithArrayIn(true))
+	q.AddColumn("UserName")
+	q.AddFilterIn("UserKey", 1, 2)
+	if s, _, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users WHERE UserKey IN (?, ?);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
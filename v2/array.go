@@ -0,0 +1,101 @@
+package querybuilder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Contains matches the rows where the array column contains all the elements of the array value,
+// such as tags @> $1. It is supported on PostgreSQL and DuckDB.
+func Contains(column string, value interface{}) Cond {
+	return Cond{Column: column, Operator: OpContains, Value: value}
+}
+
+// ContainedBy matches the rows where the elements of the array column are all in the array value,
+// such as tags <@ $1. It is supported on PostgreSQL and DuckDB.
+func ContainedBy(column string, value interface{}) Cond {
+	return Cond{Column: column, Operator: OpContainedBy, Value: value}
+}
+
+// Overlaps matches the rows where the array column and the array value have elements in common,
+// such as tags && $1. It is supported on PostgreSQL and DuckDB.
+func Overlaps(column string, value interface{}) Cond {
+	return Cond{Column: column, Operator: OpOverlaps, Value: value}
+}
+
+// AddFilterContains adds a filter that matches the rows where the array column contains all the elements of the value
+func (qb *QueryBuilder) AddFilterContains(column string, value interface{}) *QueryBuilder {
+	return qb.Where(Contains(column, value))
+}
+
+// isArray reports whether a read value is an array, a slice of a basic type other than []byte
+func isArray(value interface{}) bool {
+	t := reflect.TypeOf(value)
+	return t != nil && t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+// arrayOperator reports whether the operator compares arrays
+func arrayOperator(op Operator) bool {
+	return op == OpContains || op == OpContainedBy || op == OpOverlaps
+}
+
+// checkArrays returns an error for the array values and operators that the dialect does not have.
+// The values must be resolved.
+func (qb *QueryBuilder) checkArrays() error {
+	unsupported := func(what, column string) error {
+		return fmt.Errorf("%w: %s of %s on %s", ErrNotSupported, what, column, qb.Dialect)
+	}
+	arrays := qb.Dialect.Supports(ARRAY)
+	for _, v := range qb.Values {
+		if !arrays && v.sqlstring && isArray(v.value) {
+			return unsupported("array value", v.column)
+		}
+	}
+	check := func(column string, op Operator, value interface{}) error {
+		if arrayOperator(op) && qb.Dialect != POSTGRES && qb.Dialect != DUCKDB {
+			return unsupported(string(op)+" operator", column)
+		}
+		if !arrays && isArray(value) {
+			return unsupported("array value", column)
+		}
+		return nil
+	}
+	for _, f := range qb.Filter {
+		var err error
+		switch {
+		case f.tree != nil:
+			f.tree.conds(func(c Cond) {
+				if err == nil {
+					err = check(c.Column, c.Operator, c.Value)
+				}
+			})
+		case !f.containsvalue:
+			err = check(f.expression, f.op, f.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// arrayLiteral renders an array as an ARRAY[...] literal of the elements
+func (qb *QueryBuilder) arrayLiteral(value interface{}) (string, error) {
+	if qb.Dialect != POSTGRES && qb.Dialect != DUCKDB {
+		return "", fmt.Errorf("%w: %T literal on %s", ErrNotSupported, value, qb.Dialect)
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Len() == 0 {
+		return "'{}'", nil
+	}
+	items := make([]string, rv.Len())
+	for i := range items {
+		lit, err := qb.sqlLiteral(rv.Index(i).Interface())
+		if err != nil {
+			return "", err
+		}
+		items[i] = lit
+	}
+	return "ARRAY[" + strings.Join(items, ", ") + "]", nil
+}
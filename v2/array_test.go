@@ -0,0 +1,47 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestArrays(t *testing.T) {
+	q := New(WithTableName("Posts"), WithCommand(UPDATE), WithDialect(POSTGRES))
+	q.AddValue("Tags", []string{"go", "sql"})
+	q.AddValue("Scores", []int64{})
+	q.AddFilterContains("Tags", []string{"go"})
+	q.Where(Overlaps("Readers", []int{1, 2}), ContainedBy("Flags", []bool{true}))
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "UPDATE Posts SET Tags = $1, Scores = $2 WHERE Tags @> $3 AND Readers && $4 AND Flags <@ $5;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	want := []interface{}{[]string{"go", "sql"}, []int64{}, []string{"go"}, []int{1, 2}, []bool{true}}
+	if !reflect.DeepEqual(v, want) {
+		t.Errorf("got %#v, want %#v", v, want)
+	}
+
+	lit, err := q.BuildLiteral()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "UPDATE Posts SET Tags = ARRAY['go', 'sql'], Scores = '{}' WHERE Tags @> ARRAY['go'] AND Readers && ARRAY[1, 2] AND Flags <@ ARRAY[TRUE];"; lit != want {
+		t.Errorf("got %q, want %q", lit, want)
+	}
+
+	q = New(WithTableName("Posts"), WithDialect(MYSQL))
+	q.AddColumn("Title")
+	q.AddFilter("Tags", []string{"go"})
+	if _, _, err = q.Build(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("got %v, want ErrNotSupported", err)
+	}
+	q = New(WithTableName("Posts"), WithDialect(SNOWFLAKE))
+	q.AddColumn("Title")
+	q.AddFilterContains("Tags", []string{"go"})
+	if _, _, err = q.Build(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("got %v, want ErrNotSupported", err)
+	}
+}
@@ -0,0 +1,126 @@
+package querybuilder
+
+import (
+	"context"
+	"strings"
+)
+
+// AST describes a built query, so that tools can analyze or verify a query without parsing its SQL.
+// It is a snapshot of the build. Changing it does not change the builder.
+type AST struct {
+	Command Command  // Command of the query
+	Table   string   // Table of the query
+	Alias   string   // Alias of the table
+	Query   string   // The built query
+	Clauses []Clause // Clauses of the query, in the order they are rendered
+	Params  []Param  // Parameter slots of the query, in the order of the arguments
+}
+
+// Clause is a clause of a query with its expressions. The keyword is SELECT, SET, VALUES, WHERE,
+// GROUP BY, ORDER BY or LIMIT.
+type Clause struct {
+	Keyword     string
+	Expressions []Expression
+}
+
+// Expression is an expression of a clause
+type Expression struct {
+	SQL    string // SQL of the expression, with its placeholders
+	Column string // Column of the expression. Filter expressions, condition trees and filter functions have no column
+	Params []int  // Indexes of the parameter slots bound by the expression
+}
+
+// Param is a parameter slot of a query
+type Param struct {
+	Column string      // Column or filter expression of the value, as passed to CaptureArgs
+	Value  interface{} // Bound value
+}
+
+// astRecorder records the expressions of the clauses while a query is rendered
+type astRecorder struct {
+	clauses   []Clause
+	funcSlots int // parameter slots of the filter functions rendered so far
+}
+
+// AST builds the query and returns its description. The ParameterOffset of the builder is not advanced.
+// The expressions of the additional rows of an INSERT command, of the QUALIFY clause and of the
+// pagination are not described, although their values are in the parameter slots.
+func (qb *QueryBuilder) AST() (*AST, error) {
+	w := *qb
+	w.memo = nil
+	w.MemoizeBuild = false
+	w.CacheQueries = false
+	w.metrics = nil
+	w.ast = &astRecorder{}
+	var params []Param
+	w.captureArgs = func(column string, value interface{}) {
+		params = append(params, Param{Column: column, Value: value})
+	}
+	query, _, _, err := w.buildAt(context.Background(), qb.ParameterOffset)
+	if err != nil {
+		return nil, err
+	}
+	a := &AST{
+		Command: qb.CommandType,
+		Table:   qb.TableName,
+		Alias:   qb.TableAlias,
+		Query:   query,
+		Clauses: w.ast.clauses,
+		Params:  params,
+	}
+	for _, g := range qb.Group {
+		a.add("GROUP BY", Expression{SQL: g, Column: g})
+	}
+	for _, o := range qb.Order {
+		dir := " ASC"
+		if o.order == DESC {
+			dir = " DESC"
+		}
+		a.add("ORDER BY", Expression{SQL: o.column + dir, Column: o.column})
+	}
+	if qb.ResultLimit != "" {
+		a.add("LIMIT", Expression{SQL: qb.ResultLimit})
+	}
+	return a, nil
+}
+
+// add adds an expression to the last clause, or to a new clause when the keyword is different
+func (a *AST) add(keyword string, e Expression) {
+	if n := len(a.Clauses); n > 0 && a.Clauses[n-1].Keyword == keyword {
+		a.Clauses[n-1].Expressions = append(a.Clauses[n-1].Expressions, e)
+		return
+	}
+	a.Clauses = append(a.Clauses, Clause{Keyword: keyword, Expressions: []Expression{e}})
+}
+
+// record records an expression rendered for AST with the placeholders of the builder between from and to
+func (qb *QueryBuilder) record(keyword, sql, column string, from, to int) {
+	if qb.ast == nil {
+		return
+	}
+	e := Expression{SQL: strings.TrimSpace(sql), Column: column}
+	for i := from; i < to; i++ {
+		e.Params = append(e.Params, i+qb.ast.funcSlots)
+	}
+	a := AST{Clauses: qb.ast.clauses}
+	a.add(keyword, e)
+	qb.ast.clauses = a.Clauses
+}
+
+// recordFunc records a filter of a filter function rendered after the placeholders of the builder
+func (qb *QueryBuilder) recordFunc(sql string, phcnt int) {
+	if qb.ast == nil {
+		return
+	}
+	n := countPlaceholders(sql, qb.ParameterChar, qb.ParameterInSequence, qb.StringEnclosingChar)
+	qb.record("WHERE", sql, "", phcnt, phcnt+n)
+	qb.ast.funcSlots += n
+}
+
+// column returns the column of a filter that compares a column
+func (f queryFilter) column() string {
+	if f.containsvalue || f.tree != nil || f.fulltext != nil {
+		return ""
+	}
+	return f.expression
+}
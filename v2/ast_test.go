@@ -0,0 +1,63 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAST(t *testing.T) {
+	q := New(WithTableName("Users"), WithCommand(UPDATE), WithDialect(POSTGRES))
+	q.AddValue("UserName", "a")
+	q.AddValue("Active", true)
+	q.AddFilter("UserKey", 5)
+	q.AddFilterIn("Status", "A", "B")
+	q.AddFilterExp("DeletedAt IS NULL")
+	q.FilterFunc = func(offset int, char string, inSeq bool) ([]string, []interface{}) {
+		return []string{"GroupKey = $6"}, []interface{}{9}
+	}
+	a, err := q.AST()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if q.ParameterOffset != 0 {
+		t.Errorf("unexpected parameter offset %d", q.ParameterOffset)
+	}
+	want := []Clause{
+		{Keyword: "SET", Expressions: []Expression{
+			{SQL: "UserName = $1", Column: "UserName", Params: []int{0}},
+			{SQL: "Active = $2", Column: "Active", Params: []int{1}},
+		}},
+		{Keyword: "WHERE", Expressions: []Expression{
+			{SQL: "UserKey = $3", Column: "UserKey", Params: []int{2}},
+			{SQL: "Status IN ($4, $5)", Column: "Status", Params: []int{3, 4}},
+			{SQL: "DeletedAt IS NULL"},
+			{SQL: "GroupKey = $6", Params: []int{5}},
+		}},
+	}
+	if !reflect.DeepEqual(a.Clauses, want) {
+		t.Errorf("got %+v, want %+v", a.Clauses, want)
+	}
+	params := []Param{{"UserName", "a"}, {"Active", true}, {"UserKey", 5}, {"Status", "A"}, {"Status", "B"}, {"", 9}}
+	if !reflect.DeepEqual(a.Params, params) {
+		t.Errorf("got %v, want %v", a.Params, params)
+	}
+	if s, _, _ := q.Build(); a.Query != s {
+		t.Errorf("got %q, want %q", a.Query, s)
+	}
+
+	q = New(WithTableName("Users"))
+	q.AddColumn("UserName")
+	q.AddGroup("UserName")
+	q.AddOrder("UserName", DESC)
+	q.ResultLimit = "10"
+	if a, err = q.AST(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	var keywords []string
+	for _, c := range a.Clauses {
+		keywords = append(keywords, c.Keyword)
+	}
+	if !reflect.DeepEqual(keywords, []string{"SELECT", "GROUP BY", "ORDER BY", "LIMIT"}) {
+		t.Errorf("unexpected clauses: %v", keywords)
+	}
+}
@@ -0,0 +1,49 @@
+package querybuilder
+
+import "context"
+
+// UserFunc returns the user of a build from its context
+type UserFunc func(ctx context.Context) interface{}
+
+// AuditUser sets the columns that record the user who created and last updated a row. The user is taken
+// from the context of the build by fn. INSERT commands set both columns, and UPDATE commands set the updated
+// column, unless the values are added to the builder. Upserts keep the creator of an existing row.
+// Either column can be empty, and nothing is set when fn returns nil.
+func AuditUser(fn UserFunc, created, updated string) Option {
+	return func(q *QueryBuilder) error {
+		q.auditUser = fn
+		q.createdByColumn = created
+		q.updatedByColumn = updated
+		return nil
+	}
+}
+
+// addAuditUser adds the audit user values of the command. The values must be resolved.
+func (qb *QueryBuilder) addAuditUser(ctx context.Context) {
+	if qb.auditUser == nil {
+		return
+	}
+	var cols []string
+	switch qb.CommandType {
+	case INSERT:
+		cols = []string{qb.createdByColumn, qb.updatedByColumn}
+	case UPDATE:
+		cols = []string{qb.updatedByColumn}
+		// an UPDATE command without changes stays without changes
+		if qb.original != nil && !qb.writes() {
+			return
+		}
+	default:
+		return
+	}
+	user := realValue(qb.auditUser(ctx))
+	if isNil(user) {
+		return
+	}
+	for _, c := range cols {
+		if c == "" || qb.hasValue(c) {
+			continue
+		}
+		qb.addGenerated(queryValue{column: c, value: user, sqlstring: true})
+	}
+}
@@ -0,0 +1,85 @@
+package querybuilder
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type userKey struct{}
+
+func TestAuditUser(t *testing.T) {
+	user := AuditUser(func(ctx context.Context) interface{} { return ctx.Value(userKey{}) }, "created_by", "updated_by")
+	ctx := context.WithValue(context.Background(), userKey{}, "ann")
+
+	q := New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(POSTGRES), user)
+	q.AddValue("Customer", "ACME")
+	s, v, err := q.BuildContext(ctx)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (Customer, created_by, updated_by) VALUES ($1,$2,$3);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"ACME", "ann", "ann"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+	if len(q.Values) != 1 || len(q.Columns) != 1 {
+		t.Errorf("builder values were changed: %v", q.Values)
+	}
+
+	// a value added by the caller is kept
+	q = New(WithTableName("Orders"), WithCommand(UPDATE), WithDialect(POSTGRES), user)
+	q.AddValue("Customer", "ACME")
+	q.AddValue("updated_by", "system")
+	q.AddFilter("OrderKey", 5)
+	if s, v, err = q.BuildContext(ctx); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "UPDATE Orders SET Customer = $1, updated_by = $2 WHERE OrderKey = $3;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"ACME", "system", 5}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	// no user, no audit columns
+	q = New(WithTableName("Orders"), WithCommand(INSERT), user)
+	q.AddValue("Customer", "ACME")
+	if s, _, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (Customer) VALUES (?);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	// upserts keep the creator of the existing row
+	q = New(WithTableName("Orders"), WithDialect(POSTGRES), user)
+	q.Upsert("OrderKey")
+	q.AddValue("OrderKey", 5)
+	if s, _, err = q.BuildContext(ctx); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (OrderKey, created_by, updated_by) VALUES ($1,$2,$3) ON CONFLICT (OrderKey) DO UPDATE SET updated_by = EXCLUDED.updated_by;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
+
+func TestAuditUserRows(t *testing.T) {
+	user := AuditUser(func(ctx context.Context) interface{} { return ctx.Value(userKey{}) }, "created_by", "updated_by")
+	ctx := context.WithValue(context.Background(), userKey{}, "ann")
+
+	q := New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(POSTGRES), user)
+	q.AddValue("Customer", "ACME")
+	q.AddRow("Globex")
+	s, v, err := q.BuildContext(ctx)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (Customer, created_by, updated_by) VALUES ($1,$2,$3),($4,$5,$6);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"ACME", "ann", "ann", "Globex", "ann", "ann"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
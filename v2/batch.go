@@ -0,0 +1,99 @@
+package querybuilder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// TxBeginner starts a transaction. It is implemented by *sql.DB and *sql.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// Batch accumulates query builders that are executed in a single transaction
+type Batch struct {
+	Builders []*QueryBuilder // Builders of the batch, in the order of execution
+	Combine  bool            // When true, the statements are sent in a single round-trip. The driver must allow multiple statements
+}
+
+// NewBatch creates a batch of query builders
+func NewBatch(builders ...*QueryBuilder) *Batch {
+	return &Batch{Builders: builders}
+}
+
+// Add adds query builders to the batch
+func (b *Batch) Add(builders ...*QueryBuilder) *Batch {
+	b.Builders = append(b.Builders, builders...)
+	return b
+}
+
+// Build builds the statements of the batch into one query. The parameter sequence continues
+// from one statement to the next, so that the placeholders are unique across the query.
+func (b *Batch) Build() (query string, args []interface{}, err error) {
+	return b.BuildContext(context.Background())
+}
+
+// BuildContext builds the statements of the batch into one query with the request-scoped settings of the context
+func (b *Batch) BuildContext(ctx context.Context) (query string, args []interface{}, err error) {
+	var sb strings.Builder
+	if args, err = b.buildTo(ctx, &sb); err != nil {
+		return "", nil, err
+	}
+	return sb.String(), args, nil
+}
+
+// ExecContext executes the statements of the batch in a transaction. When a statement fails,
+// the transaction is rolled back and none of the statements take effect. When Combine is true,
+// the statements are built with BuildContext and sent as one, returning a single result.
+func (b *Batch) ExecContext(ctx context.Context, db TxBeginner) ([]sql.Result, error) {
+	var (
+		queries []string
+		argsets [][]interface{}
+	)
+	if b.Combine {
+		q, a, err := b.BuildContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		queries, argsets = []string{q}, [][]interface{}{a}
+	} else {
+		for i, qb := range b.Builders {
+			q, a, _, err := qb.buildAt(ctx, qb.ParameterOffset)
+			if err != nil {
+				return nil, fmt.Errorf("batch statement %d: %w", i+1, err)
+			}
+			queries = append(queries, q)
+			argsets = append(argsets, a)
+		}
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]sql.Result, 0, len(queries))
+	for i, q := range queries {
+		res, err := tx.ExecContext(ctx, q, argsets[i]...)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("batch statement %d: %w", i+1, err)
+		}
+		results = append(results, res)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// buildAt builds the query with the parameter sequence starting at the offset. It returns the
+// parameter sequence after the query and keeps the ParameterOffset of the builder unchanged.
+func (qb *QueryBuilder) buildAt(ctx context.Context, offset int) (query string, args []interface{}, next int, err error) {
+	saved := qb.ParameterOffset
+	qb.ParameterOffset = offset
+	query, args, err = qb.build(ctx)
+	next = qb.ParameterOffset
+	qb.ParameterOffset = saved
+	return
+}
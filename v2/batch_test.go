@@ -0,0 +1,36 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBatchBuild(t *testing.T) {
+	ins := New(WithTableName("{Orders}"), WithCommand(INSERT), WithDialect(POSTGRES))
+	ins.AddValue("OrderKey", 10)
+	ins.AddValue("Status", "A")
+	upd := New(WithTableName("{Customers}"), WithCommand(UPDATE), WithDialect(POSTGRES))
+	upd.AddValue("LastOrderKey", 10)
+	upd.AddFilter("CustomerKey", 7)
+
+	b := NewBatch(ins).Add(upd)
+	s, v, err := b.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "INSERT INTO Orders (OrderKey, Status) VALUES ($1,$2); UPDATE Customers SET LastOrderKey = $3 WHERE CustomerKey = $4;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{10, "A", 10, 7}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+	if ins.ParameterOffset != 0 || upd.ParameterOffset != 0 {
+		t.Errorf("parameter offsets changed: %d, %d", ins.ParameterOffset, upd.ParameterOffset)
+	}
+
+	// building again renders the same query
+	if s2, _, _ := b.Build(); s2 != s {
+		t.Errorf("rebuilt %q, want %q", s2, s)
+	}
+}
@@ -0,0 +1,112 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// maxCachedQueries is the number of query shapes kept in the cache
+const maxCachedQueries = 4096
+
+// cachedQuery is a built query of a shape
+type cachedQuery struct {
+	query string // query before the comment
+	next  int    // parameter sequence after the query
+}
+
+var (
+	queryCache      sync.Map
+	queryCacheCount atomic.Int64
+)
+
+// WithQueryCache sets the condition to cache the queries of a builder by their shape.
+//
+// The shape is made of the table, the command, the dialect and placeholder settings, the columns, whether each value
+// is NULL, skipped or raw, the filters, the order, the group, the row limits and the schema. Builds of a cached shape
+// skip the rendering of the query and only collect the arguments. Builders with filter functions or a token resolver
+// are never cached.
+func WithQueryCache(enabled bool) Option {
+	return func(q *QueryBuilder) error {
+		q.CacheQueries = enabled
+		return nil
+	}
+}
+
+// cacheQuery stores the query of a shape until the cache is full
+func (qb *QueryBuilder) cacheQuery(key string, cq cachedQuery) {
+	if queryCacheCount.Load() >= maxCachedQueries {
+		return
+	}
+	if _, loaded := queryCache.LoadOrStore(key, cq); !loaded {
+		queryCacheCount.Add(1)
+	}
+}
+
+// shapeKey returns the key of the query shape of the builder. The values must be resolved.
+func (qb *QueryBuilder) shapeKey(schema string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d|%q|%d|%d|%q|%t|%d|%q|%q|%q|%d|%q|%t|%t|%t|%t|%t|%t|%t|%t|%d|%d|%d|%q|%q|%q|%t|%t\n",
+		qb.CommandType, qb.TableName, qb.Dialect, qb.DialectVersion, qb.ParameterChar, qb.ParameterInSequence,
+		qb.ParameterOffset, qb.ReservedWordEscapeChar, qb.StringEnclosingChar, qb.StringEscapeChar,
+		qb.ResultLimitPosition, qb.ResultLimit, qb.InterpolateTables, qb.QuoteTables, qb.CompactSQL,
+		qb.RowNumberPagination, qb.AllowWriteLimit, qb.StrictMode, qb.RequireWhere, qb.VerifyPlaceholders,
+		qb.Conflict, qb.pageOffset, qb.pageSize, schema, qb.qualifyExpr, qb.orderList(),
+		qb.original != nil, qb.Warnings == WarnError)
+	fmt.Fprintf(&sb, "%q|%q|%q|%q|%q|%t|%d|%t|%v\n", qb.UpsertKeys, qb.ReturnColumns, qb.Group, qb.IdentityColumn, qb.TableAlias, qb.tempTable, qb.Layout, qb.ArrayIn, qb.enums)
+	for _, v := range qb.Values {
+		fmt.Fprintf(&sb, "v%q|%t|%t|%t|%t", v.column, v.sqlstring, v.skip, v.forcenull, v.null)
+		if !v.sqlstring && !v.null {
+			fmt.Fprintf(&sb, "|%T:%v", v.value, v.value)
+		}
+		sb.WriteString("\n")
+	}
+	for _, r := range qb.rows {
+		sb.WriteString("r")
+		for idx, v := range qb.Values {
+			switch rv := rowValue(v, r, idx); {
+			case isNil(rv):
+				sb.WriteString("0")
+			case !v.sqlstring:
+				fmt.Fprintf(&sb, "|%T:%v|", rv, rv)
+			default:
+				sb.WriteString("1")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "s%q", qb.sourceColumns)
+	for i := range qb.sourceRows {
+		sb.WriteString("|")
+		for j := range qb.sourceColumns {
+			if isNil(qb.sourceValue(i, j)) {
+				sb.WriteString("0")
+			} else {
+				sb.WriteString("1")
+			}
+		}
+	}
+	sb.WriteString("\n")
+	for _, f := range qb.Filter {
+		fmt.Fprintf(&sb, "f%q|%t|%t|%q|%t|%t|%t|%q", f.expression, f.containsvalue, f.in, f.op, f.ci, f.escape, isNil(f.value), f.jsonPath)
+		if f.tree != nil {
+			treeShape(&sb, *f.tree)
+		}
+		if f.fulltext != nil {
+			sb.WriteString("|fulltext")
+		}
+		if f.quantifier != "" {
+			fmt.Fprintf(&sb, "|%s%q", f.quantifier, f.subSQL)
+		}
+		for _, v := range f.values {
+			if isNil(v) {
+				sb.WriteString("0")
+			} else {
+				sb.WriteString("1")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,61 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQueryCache(t *testing.T) {
+	build := func(name interface{}, key int) (string, []interface{}) {
+		q := New(WithTableName("{CachedUsers}"), WithCommand(UPDATE), WithDialect(POSTGRES), WithQueryCache(true))
+		q.AddValue("UserName", name)
+		q.AddFilter("UserKey", key)
+		s, v, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		return s, v
+	}
+
+	s1, _ := build("eaglebush", 5)
+	q := New(WithTableName("{CachedUsers}"), WithCommand(UPDATE), WithDialect(POSTGRES))
+	q.AddValue("UserName", "eagle")
+	q.AddFilter("UserKey", 6)
+	q.resolveValues()
+	if _, ok := queryCache.Load(q.shapeKey("")); !ok {
+		t.Fatal("query shape was not cached")
+	}
+
+	s2, v2 := build("eagle", 6)
+	if s2 != s1 || !reflect.DeepEqual(v2, []interface{}{"eagle", 6}) {
+		t.Errorf("got %q %v from the cache, want %q", s2, v2, s1)
+	}
+
+	// a NULL value is another shape
+	s3, v3 := build(nil, 7)
+	if want := "UPDATE CachedUsers SET UserName = NULL WHERE UserKey = $1;"; s3 != want || !reflect.DeepEqual(v3, []interface{}{7}) {
+		t.Errorf("got %q %v, want %q", s3, v3, want)
+	}
+}
+
+func TestQueryCacheTokenResolver(t *testing.T) {
+	resolver := func(db string) TokenResolver {
+		return func(name string) (string, bool) {
+			if name == "db" {
+				return db, true
+			}
+			return "", false
+		}
+	}
+	for _, db := range []string{"TenantA", "TenantB"} {
+		q := New(WithTableName("{db.dbo.Orders}"), WithDialect(SQLSERVER), WithQueryCache(true), WithTokenResolver(resolver(db)))
+		q.AddColumn("x")
+		s, _, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if want := "SELECT x FROM " + db + ".dbo.Orders;"; s != want {
+			t.Errorf("got %q, want %q", s, want)
+		}
+	}
+}
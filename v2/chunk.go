@@ -0,0 +1,251 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Chunk is a statement with its arguments
+type Chunk struct {
+	Query string        // SQL of the statement
+	Args  []interface{} // Arguments of the statement
+}
+
+// AddRow adds a row to a multi-row INSERT command. The values are in the order of the columns
+// added with AddValue, which set the first row. The columns written are those of the first row,
+// and missing values are written as NULL. The Default and MatchToNull options of a column apply to every row,
+// and the values of raw columns are written as is.
+func (qb *QueryBuilder) AddRow(values ...interface{}) *QueryBuilder {
+	qb.touch()
+	qb.rows = append(qb.rows, values)
+	return qb
+}
+
+// BuildChunked builds the query into statements that have at most maxParams arguments each,
+// for engines that limit the number of parameters, such as SQL Server with 2100 and PostgreSQL with 65535.
+//
+// A multi-row INSERT command is split by rows, and a query with IN filters is split by the values
+// of its largest IN filter. Every statement is built on its own. A query that fits is returned as one statement.
+// ErrTooManyParameters is returned when the query cannot be split to fit.
+func (qb *QueryBuilder) BuildChunked(maxParams int) ([]Chunk, error) {
+	ctx := context.Background()
+	query, args, _, err := qb.buildAt(ctx, qb.ParameterOffset)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) <= maxParams {
+		return []Chunk{{Query: query, Args: args}}, nil
+	}
+	switch {
+	case qb.CommandType == INSERT && len(qb.rows) > 0:
+		return qb.chunkRows(ctx, maxParams)
+	case qb.CommandType != INSERT:
+		big := -1
+		for i, f := range qb.Filter {
+			if f.in && (big < 0 || len(f.values) > len(qb.Filter[big].values)) {
+				big = i
+			}
+		}
+		if big >= 0 {
+			return qb.chunkIn(ctx, big, maxParams-(len(args)-len(qb.Filter[big].values)), maxParams)
+		}
+	}
+	return nil, fmt.Errorf("%w: %d arguments, %d allowed", ErrTooManyParameters, len(args), maxParams)
+}
+
+// chunkRows splits the rows of a multi-row INSERT command
+func (qb *QueryBuilder) chunkRows(ctx context.Context, maxParams int) ([]Chunk, error) {
+	n, err := qb.rowParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	per := maxParams
+	if n > 0 {
+		per = maxParams / n
+	}
+	if per == 0 {
+		return nil, fmt.Errorf("%w: %d arguments in a row, %d allowed", ErrTooManyParameters, n, maxParams)
+	}
+	rows := make([][]interface{}, 0, len(qb.rows)+1)
+	first := make([]interface{}, len(qb.Values))
+	for i, v := range qb.Values {
+		first[i] = v.value
+	}
+	rows = append(append(rows, first), qb.rows...)
+	var chunks []Chunk
+	for i := 0; i < len(rows); i += per {
+		end := i + per
+		if end > len(rows) {
+			end = len(rows)
+		}
+		c := qb.clone()
+		for k := range c.Values {
+			c.Values[k].value = nil
+			if k < len(rows[i]) {
+				c.Values[k].value = rows[i][k]
+			}
+		}
+		c.rows = rows[i+1 : end]
+		query, args, _, err := c.buildAt(ctx, qb.ParameterOffset)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) > maxParams {
+			return nil, fmt.Errorf("%w: %d arguments, %d allowed", ErrTooManyParameters, len(args), maxParams)
+		}
+		chunks = append(chunks, Chunk{Query: query, Args: args})
+	}
+	return chunks, nil
+}
+
+// rowParams returns the most arguments of a row of an INSERT command, with the values that
+// the builder generates for every row, such as the timestamps, the audit user and the tenant
+func (qb *QueryBuilder) rowParams(ctx context.Context) (int, error) {
+	tenant, err := qb.tenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+	w := qb.resolved(ctx)
+	w.applyTenant(tenant)
+	n := 0
+	for _, v := range w.Values {
+		if v.skip && !v.forcenull || !v.sqlstring {
+			continue
+		}
+		n++
+	}
+	return n, nil
+}
+
+// chunkIn splits the values of an IN filter
+func (qb *QueryBuilder) chunkIn(ctx context.Context, idx, per, maxParams int) ([]Chunk, error) {
+	if per <= 0 {
+		return nil, fmt.Errorf("%w: no room for the values of %s", ErrTooManyParameters, qb.Filter[idx].expression)
+	}
+	values := qb.Filter[idx].values
+	var chunks []Chunk
+	for i := 0; i < len(values); i += per {
+		end := i + per
+		if end > len(values) {
+			end = len(values)
+		}
+		c := qb.clone()
+		c.Filter[idx].values = values[i:end]
+		query, args, _, err := c.buildAt(ctx, qb.ParameterOffset)
+		if err != nil {
+			return nil, err
+		}
+		if len(args) > maxParams {
+			return nil, fmt.Errorf("%w: %d arguments, %d allowed", ErrTooManyParameters, len(args), maxParams)
+		}
+		chunks = append(chunks, Chunk{Query: query, Args: args})
+	}
+	return chunks, nil
+}
+
+// clone copies the builder with its own values and filters
+func (qb *QueryBuilder) clone() *QueryBuilder {
+	c := *qb
+	c.Values = append([]queryValue(nil), qb.Values...)
+	c.Filter = append([]queryFilter(nil), qb.Filter...)
+	return &c
+}
+
+// inClause renders an IN filter
+func (qb *QueryBuilder) inClause(f queryFilter, paramcnt, phcnt *int) string {
+	if len(f.values) == 0 {
+		return "1 = 0"
+	}
+	if qb.arrayIn(f) {
+		*phcnt++
+		return f.expression + " = ANY(" + qb.placeholder(paramcnt) + ")"
+	}
+	items := make([]string, len(f.values))
+	for i, v := range f.values {
+		if isNil(v) {
+			items[i] = "NULL"
+			continue
+		}
+		items[i] = qb.enumCast(f.expression, qb.placeholder(paramcnt))
+		if f.ci {
+			items[i] = "LOWER(" + items[i] + ")"
+		}
+		*phcnt++
+	}
+	if f.ci {
+		return "LOWER(" + f.expression + ") IN (" + strings.Join(items, ", ") + ")"
+	}
+	return f.expression + " IN (" + strings.Join(items, ", ") + ")"
+}
+
+// rowsClause renders the additional rows of an INSERT command for the written columns.
+// Raw columns are written as is in every row, like in the first row.
+func (qb *QueryBuilder) rowsClause(written []int, paramcnt, phcnt *int) (string, error) {
+	var sb strings.Builder
+	for _, r := range qb.rows {
+		sb.WriteString(",(")
+		for i, idx := range written {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			v := qb.Values[idx]
+			rv := rowValue(v, r, idx)
+			switch {
+			case isNil(rv):
+				sb.WriteString("NULL")
+			case !v.sqlstring:
+				raw, ok := rv.(string)
+				if !ok {
+					if qb.StrictMode {
+						return "", fmt.Errorf("%w: raw value of %s is not a string", ErrStrict, v.column)
+					}
+					raw = fmt.Sprint(rv)
+				}
+				sb.WriteString(raw)
+			default:
+				sb.WriteString(qb.enumCast(v.column, qb.placeholder(paramcnt)))
+				*phcnt++
+			}
+		}
+		sb.WriteString(")")
+	}
+	return sb.String(), nil
+}
+
+// rowArgs appends the values of the additional rows of an INSERT command to the arguments
+func (qb *QueryBuilder) rowArgs(args []interface{}) []interface{} {
+	for _, r := range qb.rows {
+		for idx, v := range qb.Values {
+			if v.skip && !v.forcenull || !v.sqlstring {
+				continue
+			}
+			if rv := rowValue(v, r, idx); !isNil(rv) {
+				rv = qb.normalizeTime(rv)
+				args = append(args, rv)
+				qb.capture(v.column, rv)
+			}
+		}
+	}
+	return args
+}
+
+// rowValue returns the value of a column of an additional row,
+// with the default value and the MatchToNull value of the column applied.
+// The values generated by the builder, such as the timestamps, the audit user and the tenant, are the same in every row.
+func rowValue(v queryValue, row []interface{}, idx int) interface{} {
+	if v.generated {
+		return v.value
+	}
+	var rv interface{}
+	if idx < len(row) {
+		rv = realValue(row[idx])
+	}
+	if isNil(rv) && !isNil(v.defvalue) {
+		rv = v.defvalue
+	}
+	if !isNil(rv) && !isNil(v.matchtonull) && v.matchtonull == rv {
+		return nil
+	}
+	return rv
+}
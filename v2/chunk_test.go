@@ -0,0 +1,119 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMultiRowInsert(t *testing.T) {
+	q := New(WithTableName("Users"), WithCommand(INSERT), WithDialect(POSTGRES))
+	q.AddValue("UserKey", 1)
+	q.AddValue("UserName", "a")
+	q.AddRow(2, "b")
+	q.AddRow(3, nil)
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Users (UserKey, UserName) VALUES ($1,$2),($3,$4),($5,NULL);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{1, "a", 2, "b", 3}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
+
+func TestBuildChunked(t *testing.T) {
+	q := New(WithTableName("Users"), WithCommand(INSERT), WithDialect(POSTGRES))
+	q.AddValue("UserKey", 1)
+	q.AddValue("UserName", "a")
+	q.AddRow(2, "b")
+	q.AddRow(3, "c")
+
+	chunks, err := q.BuildChunked(4)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if len(chunks) != 2 ||
+		chunks[0].Query != "INSERT INTO Users (UserKey, UserName) VALUES ($1,$2),($3,$4);" ||
+		chunks[1].Query != "INSERT INTO Users (UserKey, UserName) VALUES ($1,$2);" ||
+		!reflect.DeepEqual(chunks[1].Args, []interface{}{3, "c"}) {
+		t.Errorf("unexpected chunks: %q", chunks)
+	}
+
+	q = New(WithTableName("Users"), WithDialect(SQLSERVER))
+	q.AddColumn("UserName")
+	q.AddFilter("Active", true)
+	q.AddFilterIn("UserKey", 1, 2, 3, 4, 5)
+	chunks, err = q.BuildChunked(3)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if len(chunks) != 3 ||
+		chunks[0].Query != "SELECT UserName FROM Users WHERE Active = @p1 AND UserKey IN (@p2, @p3);" ||
+		!reflect.DeepEqual(chunks[2].Args, []interface{}{true, 5}) {
+		t.Errorf("unexpected chunks: %q", chunks)
+	}
+
+	q = New(WithTableName("Users"), WithCommand(UPDATE))
+	q.AddValue("UserName", "a")
+	q.AddValue("FullName", "b")
+	if _, err := q.BuildChunked(1); !errors.Is(err, ErrTooManyParameters) {
+		t.Errorf("expected ErrTooManyParameters, got %v", err)
+	}
+}
+
+func TestBuildChunkedGenerated(t *testing.T) {
+	now := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	nowFunc = func() time.Time { return now }
+	defer func() { nowFunc = time.Now }()
+
+	q := New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(POSTGRES), Timestamps("created_at", "updated_at"))
+	q.AddValue("Customer", "ACME")
+	q.AddRow("Globex")
+	q.AddRow("Initech")
+
+	chunks, err := q.BuildChunked(4)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if len(chunks) != 3 {
+		t.Fatalf("unexpected chunks: %q", chunks)
+	}
+	for _, c := range chunks {
+		if len(c.Args) > 4 {
+			t.Errorf("%d arguments in %q", len(c.Args), c.Query)
+		}
+	}
+	if !reflect.DeepEqual(chunks[2].Args, []interface{}{"Initech", now, now}) {
+		t.Errorf("unexpected args: %v", chunks[2].Args)
+	}
+
+	if _, err := q.BuildChunked(2); !errors.Is(err, ErrTooManyParameters) {
+		t.Errorf("got %v, want %v", err, ErrTooManyParameters)
+	}
+}
+
+func TestMultiRowInsertRawAndDefault(t *testing.T) {
+	q := New(WithTableName("Users"), WithCommand(INSERT), WithDialect(POSTGRES))
+	q.AddValue("UserKey", 1)
+	q.AddValue("Created", "NOW()", IsSqlString(false))
+	q.AddValue("Status", "A", Default("N"), MatchToNull("X"))
+	q.AddRow(2, "CURRENT_TIMESTAMP")
+	q.AddRow(3, "NOW()", "X")
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "INSERT INTO Users (UserKey, Created, Status) VALUES ($1,NOW(),$2),($3,CURRENT_TIMESTAMP,$4),($5,NOW(),NULL);"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{1, "A", 2, "N", 3}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
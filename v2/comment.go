@@ -0,0 +1,68 @@
+package querybuilder
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// CommentFunc returns the tags of the comment appended to a query, such as traceparent or app
+type CommentFunc func(ctx context.Context) map[string]string
+
+// WithComment appends an sqlcommenter comment, such as /*app='billing',traceparent='00-...'*/, to the queries
+// of a builder. The tags are taken from the function with the context passed to BuildContext.
+// The keys are sorted and the keys and values are URL encoded. Tags with empty values are left out.
+func WithComment(fn CommentFunc) Option {
+	return func(q *QueryBuilder) error {
+		q.commentFunc = fn
+		return nil
+	}
+}
+
+// CommentFromContext returns a CommentFunc that takes the tags from context values. The keys of the map
+// are the tag names and the values are the context keys. Only string context values are added.
+func CommentFromContext(keys map[string]interface{}) CommentFunc {
+	return func(ctx context.Context) map[string]string {
+		tags := make(map[string]string, len(keys))
+		for tag, key := range keys {
+			if v, ok := ctx.Value(key).(string); ok {
+				tags[tag] = v
+			}
+		}
+		return tags
+	}
+}
+
+// appendComment appends the sqlcommenter comment of the tags to a query, before the statement terminator
+func appendComment(query string, tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k, v := range tags {
+		if v == "" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return query
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	sb.WriteString("/*")
+	for i, k := range keys {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(commentEscape(k) + "='" + commentEscape(tags[k]) + "'")
+	}
+	sb.WriteString("*/")
+	if strings.HasSuffix(query, ";") {
+		return query[:len(query)-1] + " " + sb.String() + ";"
+	}
+	return query + " " + sb.String()
+}
+
+// commentEscape URL encodes a comment key or value, with spaces encoded as %20
+func commentEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
@@ -0,0 +1,33 @@
+package querybuilder
+
+import (
+	"context"
+	"testing"
+)
+
+type traceKey struct{}
+
+func TestWithComment(t *testing.T) {
+	q := New(WithTableName("{Users}"), WithComment(func(ctx context.Context) map[string]string {
+		return map[string]string{"app": "billing api", "route": "/users/{id}", "empty": ""}
+	}))
+	q.AddColumn("UserName")
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users /*app='billing%20api',route='%2Fusers%2F%7Bid%7D'*/;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	q = New(WithTableName("Users"), WithComment(CommentFromContext(map[string]interface{}{"traceparent": traceKey{}})))
+	q.AddColumn("UserName")
+	ctx := context.WithValue(context.Background(), traceKey{}, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	s, _, err = q.BuildContext(ctx)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users /*traceparent='00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01'*/;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
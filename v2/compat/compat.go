@@ -0,0 +1,185 @@
+// Package compat wraps the query builder of v2 in the API of the v1 query builder, github.com/eaglebush/querybuilder,
+// so that large codebases can move to v2 one package at a time:
+//
+//	import querybuilder "github.com/eaglebush/querybuilder/v2/compat"
+//
+//	q := querybuilder.NewQueryBuilder("{Users}")
+//	q.AddColumn("UserName").AddFilter("UserKey", 5)
+//	query, args, err := q.Build()
+//
+// The queries are rendered by v2 with the legacy layout, and the v2 builder is embedded, so that
+// its fields and methods can be used while migrating. The v2 safety settings set by SetDefaultSettings
+// apply to the builders of the adapter.
+package compat
+
+import (
+	cfg "github.com/eaglebush/config"
+	qb "github.com/eaglebush/querybuilder/v2"
+)
+
+type (
+	Command     = qb.Command
+	Sort        = qb.Sort
+	Limit       = qb.Limit
+	QueryColumn = qb.QueryColumn
+)
+
+// CommandType enum
+const (
+	SELECT = qb.SELECT // Select record type
+	INSERT = qb.INSERT // Insert record type
+	UPDATE = qb.UPDATE // Update record type
+	DELETE = qb.DELETE // Delete record type
+)
+
+// Sort enum
+const (
+	ASC  = qb.ASC
+	DESC = qb.DESC
+)
+
+// Limit enum
+const (
+	FRONT = qb.FRONT
+	REAR  = qb.REAR
+)
+
+// errors
+var (
+	ErrNoTableSpecified  = qb.ErrNoTableSpecified
+	ErrNoColumnSpecified = qb.ErrNoColumnSpecified
+)
+
+// ValueOption options for adding values
+type ValueOption struct {
+	SQLString   bool        // Sets if the value is an SQL string. When true, this value is enclosed by the database client in single quotes to represent as string
+	Default     interface{} // When set to non-nil, this is the default value when the value encounters a nil
+	MatchToNull interface{} // When the primary value matches with this value, the resulting value will be set to NULL
+}
+
+// QueryBuilder is a v2 query builder with the methods of the v1 query builder
+type QueryBuilder struct {
+	*qb.QueryBuilder
+}
+
+// NewQueryBuilder - builds a new QueryBuilder object
+func NewQueryBuilder(table string) *QueryBuilder {
+	q := NewQueryBuilderBare()
+	q.TableName = table
+	return q
+}
+
+// NewQueryBuilderWithCommandType - builds a new QueryBuilder object with table name and command type
+func NewQueryBuilderWithCommandType(table string, commandType Command) *QueryBuilder {
+	q := NewQueryBuilder(table)
+	q.CommandType = commandType
+	return q
+}
+
+// NewQueryBuilderBare - builds a new QueryBuilder object without a table name.
+// Unlike the builders of v2, the tables are not interpolated by default.
+func NewQueryBuilderBare() *QueryBuilder {
+	q := qb.New(qb.WithLayout(qb.LayoutLegacy))
+	q.InterpolateTables = false
+	return &QueryBuilder{QueryBuilder: q}
+}
+
+// NewQueryBuilderWithConfig - builds a new QueryBuilder object with a table name, command type and a configuration DatabaseInfo
+func NewQueryBuilderWithConfig(table string, commandType Command, config cfg.DatabaseInfo) *QueryBuilder {
+	return newConfigBuilder(table, commandType, config, false)
+}
+
+// NewSelect is a shortcut builder for Select queries
+func NewSelect(table string, config cfg.DatabaseInfo) *QueryBuilder {
+	return newConfigBuilder(table, SELECT, config, false)
+}
+
+// NewInsert is a shortcut builder for Insert queries
+func NewInsert(table string, config cfg.DatabaseInfo) *QueryBuilder {
+	return newConfigBuilder(table, INSERT, config, false)
+}
+
+// NewUpdate is a shortcut builder for Update queries
+func NewUpdate(table string, config cfg.DatabaseInfo, skipnull bool) *QueryBuilder {
+	return newConfigBuilder(table, UPDATE, config, skipnull)
+}
+
+// NewDelete is a shortcut builder for Delete queries
+func NewDelete(table string, config cfg.DatabaseInfo) *QueryBuilder {
+	return newConfigBuilder(table, DELETE, config, false)
+}
+
+func newConfigBuilder(table string, commandType Command, config cfg.DatabaseInfo, skipnull bool) *QueryBuilder {
+	q := qb.New(
+		qb.WithTableName(table),
+		qb.WithCommand(commandType),
+		qb.WithConfig(&config),
+		qb.SkipNilWrite(skipnull),
+		qb.WithLayout(qb.LayoutLegacy))
+	return &QueryBuilder{QueryBuilder: q}
+}
+
+// AddColumn - adds a column
+func (q *QueryBuilder) AddColumn(Name string) *QueryBuilder {
+	q.QueryBuilder.AddColumn(Name)
+	return q
+}
+
+// AddColumnFixed - adds a column with specified length
+func (q *QueryBuilder) AddColumnFixed(Name string, Length int) *QueryBuilder {
+	q.QueryBuilder.AddColumnFixed(Name, Length)
+	return q
+}
+
+// AddValue adds a value enclosed with string quotes when the CommandType is INSERT or UPDATE upon building
+func (q *QueryBuilder) AddValue(Name string, Value interface{}, vo *ValueOption) *QueryBuilder {
+	if vo == nil {
+		q.QueryBuilder.AddValue(Name, Value)
+		return q
+	}
+	q.QueryBuilder.AddValue(Name, Value,
+		qb.IsSqlString(vo.SQLString),
+		qb.Default(vo.Default),
+		qb.MatchToNull(vo.MatchToNull))
+	return q
+}
+
+// SetColumnValue - sets the column value
+func (q *QueryBuilder) SetColumnValue(Name string, Value interface{}) *QueryBuilder {
+	q.QueryBuilder.SetColumnValue(Name, Value)
+	return q
+}
+
+// AddFilter adds a filter with value.
+func (q *QueryBuilder) AddFilter(Column string, Value interface{}) *QueryBuilder {
+	q.QueryBuilder.AddFilter(Column, Value)
+	return q
+}
+
+// AddFilterExp adds a specific filter expression that could not be done with AddFilter
+func (q *QueryBuilder) AddFilterExp(Expression string) *QueryBuilder {
+	q.QueryBuilder.AddFilterExp(Expression)
+	return q
+}
+
+// AddOrder - adds a column to order by into the QueryBuilder
+func (q *QueryBuilder) AddOrder(Column string, Order Sort) *QueryBuilder {
+	q.QueryBuilder.AddOrder(Column, Order)
+	return q
+}
+
+// AddGroup - adds a group by clause
+func (q *QueryBuilder) AddGroup(Group string) *QueryBuilder {
+	q.QueryBuilder.AddGroup(Group)
+	return q
+}
+
+// ParseReserveWordsChars always returns two-element array of opening and closing escape chars
+func ParseReserveWordsChars(ec string) []string {
+	return qb.ParseReserveWordsChars(ec)
+}
+
+// InterpolateTable - interpolate the tables specified with curly braces {} with a schema
+func InterpolateTable(sql string, schema string) string {
+	return qb.InterpolateTable(sql, schema)
+}
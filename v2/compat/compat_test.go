@@ -0,0 +1,41 @@
+package compat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	q := NewQueryBuilder("{Users}")
+	q.InterpolateTables = true
+	q.Schema = "carr"
+	q.ResultLimit = "100"
+	q.AddColumn("UserKey").AddColumn("UserName")
+	q.AddFilterExp("Gender = 1").AddFilter("Orientation", nil).AddFilter("Status", "A")
+	q.AddOrder("UserName", DESC)
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserKey, UserName \rFROM carr.Users\r\t WHERE Gender = 1\r\t\t AND Orientation IS NULL\r\t\t AND Status = ? ORDER BY UserName DESC LIMIT 100;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"A"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	q = NewQueryBuilderWithCommandType("Users", INSERT)
+	q.AddValue("UserName", "eaglebush", nil)
+	q.AddValue("Age", 0, &ValueOption{SQLString: true, MatchToNull: 0})
+	q.AddValue("Created", "NOW()", &ValueOption{SQLString: false})
+	s, v, err = q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Users (UserName, Age, Created) VALUES (?,NULL,NOW());"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"eaglebush"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
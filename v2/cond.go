@@ -0,0 +1,203 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Operator is the comparison operator of a condition
+type Operator string
+
+// Operator enum
+const (
+	OpEq    Operator = "="
+	OpNe    Operator = "<>"
+	OpGt    Operator = ">"
+	OpGte   Operator = ">="
+	OpLt    Operator = "<"
+	OpLte   Operator = "<="
+	OpLike  Operator = "LIKE"
+	OpILike Operator = "ILIKE" // Rendered as LOWER(column) LIKE LOWER(value) by dialects without ILIKE
+	OpIn    Operator = "IN"    // The value is a []interface{}. An empty list matches no rows
+
+	OpContains    Operator = "@>" // The array column contains the elements of the array value
+	OpContainedBy Operator = "<@" // The elements of the array column are in the array value
+	OpOverlaps    Operator = "&&" // The array column and the array value have elements in common
+
+	OpNullSafeEq Operator = "IS NOT DISTINCT FROM" // The column equals the value, or both are NULL
+)
+
+// Cond is a condition that compares a column to a value, added to a query builder with Where
+type Cond struct {
+	Column          string      `json:"column"`            // Column name
+	Operator        Operator    `json:"operator"`          // Comparison operator
+	Value           interface{} `json:"value,omitempty"`   // Value to compare to. A nil value compares with IS NULL or IS NOT NULL
+	CaseInsensitive bool        `json:"ci,omitempty"`      // When true, the column and the value are compared regardless of case
+	Escaped         bool        `json:"escaped,omitempty"` // When true, the LIKE pattern escapes its wildcards with LikeEscape
+}
+
+// LikeEscape is the escape character of the LIKE patterns of escaped conditions
+const LikeEscape = "!"
+
+// Eq matches the rows where the column equals the value, or is NULL when the value is nil
+func Eq(column string, value interface{}) Cond {
+	return Cond{Column: column, Operator: OpEq, Value: value}
+}
+
+// Ne matches the rows where the column differs from the value, or is not NULL when the value is nil
+func Ne(column string, value interface{}) Cond {
+	return Cond{Column: column, Operator: OpNe, Value: value}
+}
+
+// Gt matches the rows where the column is greater than the value
+func Gt(column string, value interface{}) Cond {
+	return Cond{Column: column, Operator: OpGt, Value: value}
+}
+
+// Gte matches the rows where the column is greater than or equal to the value
+func Gte(column string, value interface{}) Cond {
+	return Cond{Column: column, Operator: OpGte, Value: value}
+}
+
+// Lt matches the rows where the column is less than the value
+func Lt(column string, value interface{}) Cond {
+	return Cond{Column: column, Operator: OpLt, Value: value}
+}
+
+// Lte matches the rows where the column is less than or equal to the value
+func Lte(column string, value interface{}) Cond {
+	return Cond{Column: column, Operator: OpLte, Value: value}
+}
+
+// Like matches the rows where the column matches the pattern
+func Like(column string, pattern interface{}) Cond {
+	return Cond{Column: column, Operator: OpLike, Value: pattern}
+}
+
+// ILike matches the rows where the column matches the pattern regardless of case
+func ILike(column string, pattern interface{}) Cond {
+	return Cond{Column: column, Operator: OpILike, Value: pattern}
+}
+
+// EscapeLike escapes the % and _ wildcards of a value with LikeEscape, so that it matches
+// as is in the pattern of an escaped LIKE condition
+func EscapeLike(value string) string {
+	return strings.NewReplacer(LikeEscape, LikeEscape+LikeEscape, "%", LikeEscape+"%", "_", LikeEscape+"_").Replace(value)
+}
+
+// In matches the rows where the column is one of the values. An empty list matches no rows.
+func In(column string, values ...interface{}) Cond {
+	return Cond{Column: column, Operator: OpIn, Value: values}
+}
+
+// IsNull matches the rows where the column is NULL
+func IsNull(column string) Cond {
+	return Cond{Column: column, Operator: OpEq}
+}
+
+// NotNull matches the rows where the column is not NULL
+func NotNull(column string) Cond {
+	return Cond{Column: column, Operator: OpNe}
+}
+
+// IgnoreCase returns the condition compared regardless of case, as with the CaseInsensitive filter option
+func (c Cond) IgnoreCase() Cond {
+	c.CaseInsensitive = true
+	return c
+}
+
+// Escape returns the LIKE condition with an ESCAPE clause, for patterns built with EscapeLike
+func (c Cond) Escape() Cond {
+	c.Escaped = true
+	return c
+}
+
+// Where adds conditions as filters. The columns are checked by the identifier validator.
+// A condition with an unknown operator is returned as ErrInvalidOperator by Build.
+func (qb *QueryBuilder) Where(conds ...Cond) *QueryBuilder {
+	qb.touch()
+	for _, c := range conds {
+		if !c.Operator.valid() {
+			if qb.err == nil {
+				qb.err = fmt.Errorf("%w: %q on %s", ErrInvalidOperator, c.Operator, c.Column)
+			}
+			continue
+		}
+		if c.Operator == OpIn {
+			qb.Filter = append(qb.Filter, queryFilter{
+				expression: c.Column,
+				in:         true,
+				values:     inValues(c.Value),
+				ci:         c.CaseInsensitive,
+			})
+			continue
+		}
+		qb.Filter = append(qb.Filter, queryFilter{
+			expression: c.Column,
+			value:      c.Value,
+			op:         c.Operator,
+			ci:         c.CaseInsensitive,
+			escape:     c.Escaped,
+		})
+	}
+	return qb
+}
+
+func (op Operator) valid() bool {
+	switch op {
+	case OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpLike, OpILike, OpIn, OpContains, OpContainedBy, OpOverlaps, OpNullSafeEq:
+		return true
+	}
+	return false
+}
+
+// condClause renders a filter added by Where
+func (qb *QueryBuilder) condClause(f queryFilter, paramcnt, phcnt *int) string {
+	if f.op == OpIn {
+		return qb.inClause(queryFilter{expression: f.expression, in: true, values: inValues(f.value), ci: f.ci}, paramcnt, phcnt)
+	}
+	if isNil(f.value) {
+		switch f.op {
+		case OpEq, OpNullSafeEq:
+			return f.expression + " IS NULL"
+		case OpNe:
+			return f.expression + " IS NOT NULL"
+		}
+		// comparisons with NULL match no rows, as in SQL
+		return f.expression + " " + string(f.op) + " NULL"
+	}
+	if f.op == OpNullSafeEq {
+		return qb.nullSafeClause(f, paramcnt, phcnt)
+	}
+	ph := qb.placeholder(paramcnt)
+	*phcnt++
+	op := f.op
+	if f.ci && op == OpLike {
+		op = OpILike
+	}
+	if op != OpLike && op != OpILike && !arrayOperator(op) {
+		ph = qb.enumCast(f.expression, ph)
+	}
+	esc := ""
+	if f.escape && (op == OpLike || op == OpILike) {
+		esc = " ESCAPE '" + LikeEscape + "'"
+	}
+	switch {
+	case op == OpILike && !qb.Dialect.Supports(ILIKE):
+		return "LOWER(" + f.expression + ") LIKE LOWER(" + ph + ")" + esc
+	case f.ci && op != OpILike:
+		return "LOWER(" + f.expression + ") " + string(op) + " LOWER(" + ph + ")"
+	}
+	return f.expression + " " + string(op) + " " + ph + esc
+}
+
+// inValues returns the values of an IN condition. A value that is not a []interface{} is a single value.
+func inValues(value interface{}) []interface{} {
+	switch t := value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return t
+	}
+	return []interface{}{value}
+}
@@ -0,0 +1,80 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWhere(t *testing.T) {
+	q := New(WithTableName("Users"), WithDialect(POSTGRES))
+	q.AddColumn("UserName")
+	q.Where(Gte("Age", 18), Ne("Status", "X"), ILike("UserName", "a%"), IsNull("DeletedAt"), NotNull("Email"), Lt("Score", nil))
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT UserName FROM Users WHERE Age >= $1 AND Status <> $2 AND UserName ILIKE $3" +
+		" AND DeletedAt IS NULL AND Email IS NOT NULL AND Score < NULL;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{18, "X", "a%"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	q = New(WithTableName("Users"), WithDialect(MYSQL))
+	q.AddColumn("UserName")
+	q.Where(ILike("UserName", "a%"))
+	if s, _, _ = q.Build(); s != "SELECT UserName FROM Users WHERE LOWER(UserName) LIKE LOWER(?);" {
+		t.Errorf("unexpected query: %q", s)
+	}
+
+	q.Where(Cond{Column: "UserKey", Operator: "= 1 OR 1 =", Value: 1})
+	if _, _, err = q.Build(); !errors.Is(err, ErrInvalidOperator) {
+		t.Errorf("got %v, want %v", err, ErrInvalidOperator)
+	}
+}
+
+func TestCaseInsensitive(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{POSTGRES, "SELECT UserKey FROM Users WHERE LOWER(Email) = LOWER($1) AND UserName ILIKE $2 AND LOWER(Role) IN (LOWER($3), LOWER($4));"},
+		{SQLSERVER, "SELECT UserKey FROM Users WHERE LOWER(Email) = LOWER(@p1) AND LOWER(UserName) LIKE LOWER(@p2) AND LOWER(Role) IN (LOWER(@p3), LOWER(@p4));"},
+	}
+	for _, tt := range tests {
+		q := New(WithTableName("Users"), WithDialect(tt.dialect))
+		q.AddColumn("UserKey")
+		q.AddFilter("Email", "A@example.com", CaseInsensitive())
+		q.Where(Like("UserName", "ann%").IgnoreCase(), In("Role", "Admin", "Owner").IgnoreCase())
+		s, v, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if s != tt.want {
+			t.Errorf("got %q, want %q", s, tt.want)
+		}
+		if !reflect.DeepEqual(v, []interface{}{"A@example.com", "ann%", "Admin", "Owner"}) {
+			t.Errorf("unexpected args: %v", v)
+		}
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	if got, want := EscapeLike("50%_off!"), "50!%!_off!!"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	q := New(WithTableName("Users"), WithDialect(SQLSERVER))
+	q.AddColumn("UserKey")
+	q.Where(Like("UserName", EscapeLike("a_b")+"%").Escape(), ILike("Email", "%"+EscapeLike("x%")).Escape())
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT UserKey FROM Users WHERE UserName LIKE @p1 ESCAPE '!' AND LOWER(Email) LIKE LOWER(@p2) ESCAPE '!';"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
@@ -0,0 +1,73 @@
+package querybuilder
+
+import "strings"
+
+// ValueConverter converts the values of the builder before they are bound, such as to trim strings or round decimals.
+// The column is the column of the value, or the column or expression of a filter.
+type ValueConverter interface {
+	ConvertValue(column string, value interface{}) interface{}
+}
+
+// ValueConverterFunc is a function that converts the values of the builder
+type ValueConverterFunc func(column string, value interface{}) interface{}
+
+// ConvertValue calls the function
+func (f ValueConverterFunc) ConvertValue(column string, value interface{}) interface{} {
+	return f(column, value)
+}
+
+// WithValueConverters adds converters that are applied in order to the values and to the filter values of the builder
+// before they are bound. The converters get the values after pointers are read and registered types are converted.
+// Raw values, which are part of the SQL, are not converted. Pass the option to every New of a factory of builders
+// to share the converters.
+func WithValueConverters(converters ...ValueConverter) Option {
+	return func(q *QueryBuilder) error {
+		q.converters = append(q.converters[:len(q.converters):len(q.converters)], converters...)
+		return nil
+	}
+}
+
+// TrimSpace is a converter that removes the leading and trailing spaces of strings
+func TrimSpace() ValueConverter {
+	return ValueConverterFunc(func(column string, value interface{}) interface{} {
+		if s, ok := value.(string); ok {
+			return strings.TrimSpace(s)
+		}
+		return value
+	})
+}
+
+// EmptyAsNull is a converter that converts empty strings to nil, which are written as NULL, or skipped
+// by SkipNilWrite, and compared with IS NULL in the filters
+func EmptyAsNull() ValueConverter {
+	return ValueConverterFunc(func(column string, value interface{}) interface{} {
+		if s, ok := value.(string); ok && s == "" {
+			return nil
+		}
+		return value
+	})
+}
+
+// convert applies the converters of the builder to a read value
+func (qb *QueryBuilder) convert(column string, value interface{}) interface{} {
+	for _, c := range qb.converters {
+		value = c.ConvertValue(column, value)
+	}
+	return value
+}
+
+// convertTree applies the converters of the builder to the values of a copied condition tree
+func (qb *QueryBuilder) convertTree(t *Tree) {
+	if t.Cond != nil {
+		if vals, ok := t.Cond.Value.([]interface{}); ok {
+			for i, v := range vals {
+				vals[i] = qb.convert(t.Cond.Column, v)
+			}
+		} else {
+			t.Cond.Value = qb.convert(t.Cond.Column, t.Cond.Value)
+		}
+	}
+	for i := range t.Nodes {
+		qb.convertTree(&t.Nodes[i])
+	}
+}
@@ -0,0 +1,34 @@
+package querybuilder
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValueConverters(t *testing.T) {
+	upper := ValueConverterFunc(func(column string, value interface{}) interface{} {
+		if s, ok := value.(string); ok && column == "Code" {
+			return strings.ToUpper(s)
+		}
+		return value
+	})
+	name := "  eaglebush "
+	q := New(WithTableName("Users"), WithCommand(UPDATE), WithValueConverters(TrimSpace(), EmptyAsNull(), upper))
+	q.AddValue("UserName", &name)
+	q.AddValue("Email", " ")
+	q.AddValue("Updated", "NOW()", IsSqlString(false))
+	q.AddFilter("Code", " ab ")
+	q.AddFilterIn("Status", "a ", " b")
+	q.Where(Cond{Column: "Code", Operator: OpNe, Value: "x"})
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "UPDATE Users SET UserName = ?, Email = NULL, Updated = NOW() WHERE Code = ? AND Status IN (?, ?) AND Code <> ?;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"eaglebush", "AB", "a", "b", "X"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
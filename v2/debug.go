@@ -0,0 +1,118 @@
+package querybuilder
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	ssd "github.com/shopspring/decimal"
+)
+
+// DebugSQL builds the query with the values inlined as SQL literals, for logging or for running in an SQL client.
+// Strings are escaped, times are formatted as '2006-01-02 15:04:05.999999999', bytes are rendered as hexadecimal
+// literals of the dialect, such as 0x0102, and nil values are rendered as NULL.
+// The query must not be executed, since the values are not bound. Build still returns the placeholders.
+func (qb *QueryBuilder) DebugSQL() (string, error) {
+	offset := qb.ParameterOffset
+	query, args, _, err := qb.buildAt(context.Background(), offset)
+	if err != nil {
+		return "", err
+	}
+	return qb.inlineArgs(query, args, offset, qb.literal), nil
+}
+
+// inlineArgs replaces the placeholders of a query outside of string literals with the literals of the arguments
+func (qb *QueryBuilder) inlineArgs(query string, args []interface{}, offset int, literal func(interface{}) string) string {
+	pchar, enclosing := qb.ParameterChar, qb.StringEnclosingChar
+	if pchar == "" {
+		return query
+	}
+	var sb strings.Builder
+	inStr := false
+	next := 0
+	for i := 0; i < len(query); i++ {
+		if enclosing != "" && strings.HasPrefix(query[i:], enclosing) {
+			inStr = !inStr
+		}
+		if inStr || !strings.HasPrefix(query[i:], pchar) {
+			sb.WriteByte(query[i])
+			continue
+		}
+		j := i + len(pchar)
+		idx := next
+		if qb.ParameterInSequence {
+			k := j
+			for k < len(query) && query[k] >= '0' && query[k] <= '9' {
+				k++
+			}
+			if k == j {
+				sb.WriteByte(query[i])
+				continue
+			}
+			n, _ := strconv.Atoi(query[j:k])
+			idx = n - offset - 1
+			j = k
+		}
+		if idx < 0 || idx >= len(args) {
+			sb.WriteString(query[i:j])
+		} else {
+			sb.WriteString(literal(args[idx]))
+		}
+		next++
+		i = j - 1
+	}
+	return sb.String()
+}
+
+// literal renders a value as an SQL literal
+func (qb *QueryBuilder) literal(value interface{}) string {
+	value = realValue(value)
+	quote := func(s string) string {
+		return qb.StringEnclosingChar + qb.Escape(s) + qb.StringEnclosingChar
+	}
+	switch t := value.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return quote(t)
+	case bool:
+		if t {
+			return "1"
+		}
+		return "0"
+	case time.Time:
+		return quote(t.Format("2006-01-02 15:04:05.999999999"))
+	case []byte:
+		return qb.bytesLiteral(t)
+	case ssd.Decimal:
+		return t.String()
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(t)
+	case float32:
+		return strconv.FormatFloat(float64(t), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(t, 'g', -1, 64)
+	}
+	return quote(fmt.Sprint(value))
+}
+
+// bytesLiteral renders bytes as a hexadecimal literal of the dialect:
+// 0x0102 on SQL Server and MySQL, '\x0102' on PostgreSQL, HEXTORAW('0102') on Oracle,
+// FROM_HEX('0102') on BigQuery and X'0102' on the other dialects. MySQL has no empty 0x literal.
+func (qb *QueryBuilder) bytesLiteral(b []byte) string {
+	h := hex.EncodeToString(b)
+	switch {
+	case qb.Dialect == SQLSERVER, qb.Dialect == MYSQL && len(b) > 0:
+		return "0x" + h
+	case qb.Dialect == POSTGRES:
+		return `'\x` + h + "'"
+	case qb.Dialect == ORACLE:
+		return "HEXTORAW('" + h + "')"
+	case qb.Dialect == BIGQUERY:
+		return "FROM_HEX('" + h + "')"
+	}
+	return "X" + qb.StringEnclosingChar + h + qb.StringEnclosingChar
+}
@@ -0,0 +1,39 @@
+package querybuilder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDebugSQL(t *testing.T) {
+	q := New(WithTableName("Users"), WithCommand(UPDATE), WithDialect(POSTGRES))
+	q.AddValue("UserName", "O'Brien")
+	q.AddValue("Birthdate", time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC))
+	q.AddValue("Active", true)
+	q.AddValue("Notes", nil)
+	q.AddFilter("UserKey", 5)
+
+	s, err := q.DebugSQL()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := `UPDATE Users SET UserName = 'O\'Brien', Birthdate = '2001-02-03 04:05:06', Active = 1, Notes = NULL` + " WHERE UserKey = 5;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if q.ParameterOffset != 0 {
+		t.Errorf("parameter offset changed: %d", q.ParameterOffset)
+	}
+
+	q = New(WithTableName("Users"))
+	q.AddColumn("UserName")
+	q.AddFilterExp("Status <> '?'")
+	q.AddFilter("Score", 2.5)
+	s, err = q.DebugSQL()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users WHERE Status <> '?' AND Score = 2.5;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
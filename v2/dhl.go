@@ -0,0 +1,41 @@
+package querybuilder
+
+import (
+	dhl "github.com/NarsilWorks-Inc/datahelperlite"
+)
+
+// Exec builds the query and executes it with a datahelperlite helper. It returns the number of affected rows.
+func (qb *QueryBuilder) Exec(dh dhl.DataHelperLite) (int64, error) {
+	query, args, err := qb.Build()
+	if err != nil {
+		return 0, err
+	}
+	return dh.Exec(query, args...)
+}
+
+// Get builds the query, runs it with a datahelperlite helper and scans the first row into the destinations
+func (qb *QueryBuilder) Get(dh dhl.DataHelperLite, dest ...interface{}) error {
+	query, args, err := qb.Build()
+	if err != nil {
+		return err
+	}
+	return dh.QueryRow(query, args...).Scan(dest...)
+}
+
+// Query builds the query and runs it with a datahelperlite helper. The caller closes the rows.
+func (qb *QueryBuilder) Query(dh dhl.DataHelperLite) (dhl.Rows, error) {
+	query, args, err := qb.Build()
+	if err != nil {
+		return nil, err
+	}
+	return dh.Query(query, args...)
+}
+
+// QueryArray builds the query, runs it with a datahelperlite helper and stores the rows into an array
+func (qb *QueryBuilder) QueryArray(dh dhl.DataHelperLite, out interface{}) error {
+	query, args, err := qb.Build()
+	if err != nil {
+		return err
+	}
+	return dh.QueryArray(query, out, args...)
+}
@@ -0,0 +1,60 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+
+	dhl "github.com/NarsilWorks-Inc/datahelperlite"
+)
+
+// fakeHelper records the statements sent to the helper
+type fakeHelper struct {
+	dhl.DataHelperLite
+	query string
+	args  []interface{}
+}
+
+type fakeRow struct{}
+
+func (fakeRow) Scan(dest ...interface{}) error {
+	*dest[0].(*string) = "eaglebush"
+	return nil
+}
+
+func (h *fakeHelper) Exec(sql string, args ...interface{}) (int64, error) {
+	h.query, h.args = sql, args
+	return 1, nil
+}
+
+func (h *fakeHelper) QueryRow(sql string, args ...interface{}) dhl.Row {
+	h.query, h.args = sql, args
+	return fakeRow{}
+}
+
+func TestDataHelperExec(t *testing.T) {
+	q := New(WithTableName("{Users}"), WithCommand(DELETE), WithDialect(POSTGRES))
+	q.AddFilter("UserKey", 5)
+
+	dh := &fakeHelper{}
+	n, err := q.Exec(dh)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "DELETE FROM Users WHERE UserKey = $1;"; dh.query != want || n != 1 {
+		t.Errorf("got %q, want %q", dh.query, want)
+	}
+	if !reflect.DeepEqual(dh.args, []interface{}{5}) {
+		t.Errorf("unexpected args: %v", dh.args)
+	}
+
+	q = New(WithTableName("{Users}"))
+	q.AddColumn("UserName")
+	q.AddFilter("UserKey", 5)
+	var name string
+	if err := q.Get(dh, &name); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if name != "eaglebush" || dh.query != "SELECT UserName FROM Users WHERE UserKey = ?;" {
+		t.Errorf("unexpected result %q from %q", name, dh.query)
+	}
+}
@@ -0,0 +1,259 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Dialect uint8
+type Feature uint8
+type Conflict uint8
+
+// Dialect enum
+const (
+	GENERIC   Dialect = 0 // Generic SQL
+	SQLSERVER Dialect = 1 // Microsoft SQL Server
+	POSTGRES  Dialect = 2 // PostgreSQL
+	MYSQL     Dialect = 3 // MySQL and MariaDB
+	SQLITE    Dialect = 4 // SQLite
+	ORACLE    Dialect = 5 // Oracle Database
+	SNOWFLAKE Dialect = 6 // Snowflake
+	BIGQUERY  Dialect = 7 // Google BigQuery
+	DUCKDB    Dialect = 8 // DuckDB
+)
+
+// Feature enum
+const (
+	CTE       Feature = 0 // Common table expressions (WITH)
+	RETURNING Feature = 1 // Returning affected rows from INSERT, UPDATE and DELETE
+	UPSERT    Feature = 2 // Insert or update on conflict
+	LATERAL   Feature = 3 // Lateral joins or CROSS/OUTER APPLY
+	WITHTIES  Feature = 4 // FETCH ... WITH TIES or TOP ... WITH TIES
+	ARRAY     Feature = 5 // Native array types and parameters
+	QUALIFY   Feature = 6 // QUALIFY clause to filter on window functions
+	ILIKE     Feature = 7 // Case-insensitive ILIKE operator
+)
+
+// Conflict enum
+const (
+	ABORT   Conflict = 0 // Fail on conflict. This is the default
+	REPLACE Conflict = 1 // Replace the conflicting row
+	IGNORE  Conflict = 2 // Ignore the conflicting row
+)
+
+// dialectFeatures lists the features supported by each dialect. RETURNING, UPSERT, QUALIFY and ILIKE
+// are listed only where the builder renders them.
+var dialectFeatures = map[Dialect][]Feature{
+	SQLSERVER: {CTE, RETURNING, UPSERT, LATERAL, WITHTIES},
+	POSTGRES:  {CTE, RETURNING, UPSERT, LATERAL, WITHTIES, ARRAY, ILIKE},
+	MYSQL:     {CTE, UPSERT, LATERAL},
+	SQLITE:    {CTE, RETURNING, UPSERT},
+	ORACLE:    {CTE, UPSERT, LATERAL, WITHTIES},
+	SNOWFLAKE: {CTE, UPSERT, LATERAL, ARRAY, QUALIFY, ILIKE},
+	BIGQUERY:  {CTE, ARRAY, QUALIFY},
+	DUCKDB:    {CTE, RETURNING, UPSERT, LATERAL, ARRAY, QUALIFY, ILIKE},
+}
+
+// Supports checks if the dialect supports a feature. The GENERIC dialect supports none of them.
+func (d Dialect) Supports(feature Feature) bool {
+	for _, f := range dialectFeatures[d] {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the name of the dialect
+func (d Dialect) String() string {
+	switch d {
+	case SQLSERVER:
+		return "sqlserver"
+	case POSTGRES:
+		return "postgres"
+	case MYSQL:
+		return "mysql"
+	case SQLITE:
+		return "sqlite"
+	case ORACLE:
+		return "oracle"
+	case SNOWFLAKE:
+		return "snowflake"
+	case BIGQUERY:
+		return "bigquery"
+	case DUCKDB:
+		return "duckdb"
+	}
+	return "generic"
+}
+
+// String returns the name of the feature
+func (f Feature) String() string {
+	switch f {
+	case CTE:
+		return "CTE"
+	case RETURNING:
+		return "RETURNING"
+	case UPSERT:
+		return "UPSERT"
+	case LATERAL:
+		return "LATERAL"
+	case WITHTIES:
+		return "WITH TIES"
+	case ARRAY:
+		return "ARRAY"
+	case QUALIFY:
+		return "QUALIFY"
+	case ILIKE:
+		return "ILIKE"
+	}
+	return "unknown"
+}
+
+// DialectFromDriver guesses the dialect from a database/sql driver name
+func DialectFromDriver(driver string) Dialect {
+	switch d := strings.ToLower(driver); {
+	case d == "sqlserver" || d == "mssql" || strings.HasPrefix(d, "azuresql"):
+		return SQLSERVER
+	case d == "postgres" || d == "postgresql" || d == "pgx" || strings.HasPrefix(d, "pgx/"):
+		return POSTGRES
+	case d == "mysql" || d == "mariadb":
+		return MYSQL
+	case strings.HasPrefix(d, "sqlite"):
+		return SQLITE
+	case d == "oracle" || d == "godror" || d == "oci8":
+		return ORACLE
+	case d == "snowflake":
+		return SNOWFLAKE
+	case d == "bigquery":
+		return BIGQUERY
+	case d == "duckdb":
+		return DUCKDB
+	}
+	return GENERIC
+}
+
+// WithDialect sets the dialect of a query builder and applies the dialect's placeholder,
+// reserved word escape characters and row limit position.
+//
+// SQLite uses ? placeholders. Set ParameterInSequence to true to render them in the ?NNN style.
+func WithDialect(d Dialect) Option {
+	return func(q *QueryBuilder) error {
+		if d > DUCKDB {
+			return fmt.Errorf("%w: unknown dialect %d", ErrInvalidOption, d)
+		}
+		q.Dialect = d
+		switch d {
+		case SQLSERVER:
+			q.ParameterChar = "@p"
+			q.ParameterInSequence = true
+			q.ReservedWordEscapeChar = "[]"
+			q.ResultLimitPosition = FRONT
+		case POSTGRES:
+			q.ParameterChar = "$"
+			q.ParameterInSequence = true
+			q.ReservedWordEscapeChar = `"`
+		case MYSQL:
+			q.ParameterChar = "?"
+			q.ParameterInSequence = false
+			q.ReservedWordEscapeChar = "`"
+		case SQLITE:
+			q.ParameterChar = "?"
+			q.ParameterInSequence = false
+			q.ReservedWordEscapeChar = `"`
+		case ORACLE:
+			q.ParameterChar = ":"
+			q.ParameterInSequence = true
+			q.ReservedWordEscapeChar = `"`
+		case SNOWFLAKE, DUCKDB:
+			q.ParameterChar = "?"
+			q.ParameterInSequence = false
+			q.ReservedWordEscapeChar = `"`
+		case BIGQUERY:
+			q.ParameterChar = "?"
+			q.ParameterInSequence = false
+			q.ReservedWordEscapeChar = "`"
+		}
+		return nil
+	}
+}
+
+// InsertConflict sets the conflict resolution of INSERT commands.
+// It renders INSERT OR REPLACE and INSERT OR IGNORE on SQLite, and REPLACE and INSERT IGNORE on MySQL.
+func InsertConflict(c Conflict) Option {
+	return func(q *QueryBuilder) error {
+		q.Conflict = c
+		return nil
+	}
+}
+
+// insertClause returns the INSERT keywords with the conflict resolution of the dialect
+func (qb *QueryBuilder) insertClause() (string, error) {
+	if qb.Conflict == ABORT {
+		return "INSERT INTO", nil
+	}
+	switch qb.Dialect {
+	case SQLITE:
+		if qb.Conflict == REPLACE {
+			return "INSERT OR REPLACE INTO", nil
+		}
+		return "INSERT OR IGNORE INTO", nil
+	case MYSQL:
+		if qb.Conflict == REPLACE {
+			return "REPLACE INTO", nil
+		}
+		return "INSERT IGNORE INTO", nil
+	}
+	return "", fmt.Errorf("%w: INSERT conflict resolution on %s", ErrNotSupported, qb.Dialect)
+}
+
+// returningClause returns the clause that returns the affected columns. SQL Server renders
+// an OUTPUT clause placed before VALUES or WHERE, while other dialects append RETURNING.
+func (qb *QueryBuilder) returningClause() (output string, returning string, err error) {
+	if len(qb.ReturnColumns) == 0 || qb.CommandType == SELECT {
+		return "", "", nil
+	}
+	switch qb.Dialect {
+	case POSTGRES, SQLITE, DUCKDB:
+		return "", " RETURNING " + strings.Join(qb.ReturnColumns, ", "), nil
+	case SQLSERVER:
+		pfx := "INSERTED."
+		if qb.CommandType == DELETE {
+			pfx = "DELETED."
+		}
+		return " OUTPUT " + pfx + strings.Join(qb.ReturnColumns, ", "+pfx), "", nil
+	}
+	return "", "", fmt.Errorf("%w: RETURNING on %s", ErrNotSupported, qb.Dialect)
+}
+
+// WithDialectVersion sets the major version of the database engine, for dialect features
+// that depend on the version, such as FETCH FIRST on Oracle 12c and later
+func WithDialectVersion(major int) Option {
+	return func(q *QueryBuilder) error {
+		q.DialectVersion = major
+		return nil
+	}
+}
+
+// NextVal returns the expression that gets the next value of a sequence in the dialect.
+// AddValueSequence renders it for the dialect of the build.
+func (qb *QueryBuilder) NextVal(sequence string) string {
+	switch qb.Dialect {
+	case ORACLE:
+		return sequence + ".NEXTVAL"
+	case POSTGRES:
+		return "nextval('" + qb.Escape(sequence) + "')"
+	}
+	return "NEXT VALUE FOR " + sequence
+}
+
+// warnEmptyString warns that Oracle stores empty strings as NULL
+func (qb *QueryBuilder) warnEmptyString(column string, value interface{}) error {
+	if qb.Dialect != ORACLE {
+		return nil
+	}
+	if s, ok := value.(string); ok && s == "" {
+		return qb.warn("empty string value is treated as NULL by Oracle", "column", column)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDialectSupports(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		feature Feature
+		want    bool
+	}{
+		{POSTGRES, ARRAY, true},
+		{SQLSERVER, ARRAY, false},
+		{SQLITE, RETURNING, true},
+		{MYSQL, RETURNING, false},
+		{GENERIC, CTE, false},
+	}
+	for _, tt := range tests {
+		if got := tt.dialect.Supports(tt.feature); got != tt.want {
+			t.Errorf("%s supports %s: got %v, want %v", tt.dialect, tt.feature, got, tt.want)
+		}
+	}
+}
+
+// TestDialectFeaturesRendered checks the feature table against what the builder renders
+func TestDialectFeaturesRendered(t *testing.T) {
+	for d := GENERIC; d <= DUCKDB; d++ {
+		q := New(WithTableName("Users"), WithCommand(INSERT), WithDialect(d))
+		q.AddValue("UserKey", 5)
+		q.Returning("UserKey")
+		if _, _, err := q.Build(); (err == nil) != d.Supports(RETURNING) {
+			t.Errorf("%s RETURNING: supports %v, build error %v", d, d.Supports(RETURNING), err)
+		}
+
+		q = New(WithTableName("Users"), WithDialect(d))
+		q.AddValue("UserKey", 5).AddValue("UserName", "a")
+		q.Upsert("UserKey")
+		if _, _, err := q.Build(); (err == nil) != d.Supports(UPSERT) {
+			t.Errorf("%s UPSERT: supports %v, build error %v", d, d.Supports(UPSERT), err)
+		}
+
+		q = New(WithTableName("Users"), WithDialect(d))
+		q.AddColumn("UserName")
+		q.Qualify("ROW_NUMBER() OVER (ORDER BY UserKey) = 1")
+		s, _, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if got := strings.Contains(s, " QUALIFY "); got != d.Supports(QUALIFY) {
+			t.Errorf("%s QUALIFY: supports %v, rendered %q", d, d.Supports(QUALIFY), s)
+		}
+
+		q = New(WithTableName("Users"), WithDialect(d))
+		q.AddColumn("UserName")
+		q.Where(ILike("UserName", "a%"))
+		if s, _, err = q.Build(); err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if got := strings.Contains(s, " ILIKE "); got != d.Supports(ILIKE) {
+			t.Errorf("%s ILIKE: supports %v, rendered %q", d, d.Supports(ILIKE), s)
+		}
+	}
+}
+
+func TestWithDialect(t *testing.T) {
+	q := New(WithTableName("Users"), WithDialect(SQLSERVER))
+	q.AddColumn("UserName")
+	q.AddFilter("UserKey", 5)
+
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT UserName FROM Users WHERE UserKey = @p1;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if DialectFromDriver("pgx") != POSTGRES {
+		t.Errorf("pgx driver is not detected as postgres")
+	}
+}
+
+func TestSQLiteBehaviors(t *testing.T) {
+	q := New(WithTableName("Users"), WithCommand(INSERT), WithDialect(SQLITE), InsertConflict(REPLACE))
+	q.ParameterInSequence = true
+	q.AddValue("UserKey", 5)
+	q.AddValue("UserName", "eaglebush")
+	q.Returning("UserKey")
+
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT OR REPLACE INTO Users (UserKey, UserName) VALUES (?1,?2) RETURNING UserKey;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	q = New(WithTableName("Users"), WithCommand(DELETE), WithDialect(SQLITE))
+	q.AddFilter("Active", false)
+	q.ResultLimit = "10"
+	if _, _, err = q.Build(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("got %v, want %v", err, ErrNotSupported)
+	}
+	q.AllowWriteLimit = true
+	s, _, err = q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "DELETE FROM Users WHERE Active = ? LIMIT 10;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
+
+func TestReturningSQLServer(t *testing.T) {
+	q := New(WithTableName("Users"), WithCommand(UPDATE), WithDialect(SQLSERVER))
+	q.AddValue("UserName", "eaglebush")
+	q.AddFilter("UserKey", 5)
+	q.Returning("UserKey", "UserName")
+
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "UPDATE Users SET UserName = @p1 OUTPUT INSERTED.UserKey, INSERTED.UserName WHERE UserKey = @p2;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
+
+func TestOracleRowLimit(t *testing.T) {
+	q := New(WithTableName("Users"), WithDialect(ORACLE))
+	q.AddColumn("UserName")
+	q.AddFilter("Active", 1)
+	q.ResultLimit = "10"
+
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users WHERE Active = :1 FETCH FIRST 10 ROWS ONLY"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	q.DialectVersion = 11
+	q.ParameterOffset = 0
+	s, _, err = q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT * FROM (SELECT UserName FROM Users WHERE Active = :1) WHERE ROWNUM <= 10"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if nv := q.NextVal("seq_users"); nv != "seq_users.NEXTVAL" {
+		t.Errorf("got %q, want seq_users.NEXTVAL", nv)
+	}
+}
+
+func TestAddValueSequence(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{POSTGRES, "INSERT INTO Orders (OrderKey, Customer) VALUES (nextval('seq_orders'),$1);"},
+		{SQLSERVER, "INSERT INTO Orders (OrderKey, Customer) VALUES (NEXT VALUE FOR seq_orders,@p1);"},
+		{ORACLE, "INSERT INTO Orders (OrderKey, Customer) VALUES (seq_orders.NEXTVAL,:1)"},
+	}
+	for _, tt := range tests {
+		q := New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(tt.dialect), WithSettings(Settings{DenyRaw: true}))
+		q.AddValueSequence("OrderKey", "seq_orders")
+		q.AddValue("Customer", "ACME")
+		s, v, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if s != tt.want {
+			t.Errorf("got %q, want %q", s, tt.want)
+		}
+		if len(v) != 1 {
+			t.Errorf("unexpected args: %v", v)
+		}
+	}
+
+	q := New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(SQLITE))
+	q.AddValueSequence("OrderKey", "seq_orders")
+	if _, _, err := q.Build(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("got %v, want %v", err, ErrNotSupported)
+	}
+}
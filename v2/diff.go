@@ -0,0 +1,114 @@
+package querybuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Difference is a difference between two builders
+type Difference struct {
+	Path string // Field of the difference, such as Columns[1], Filter[0] or Dialect
+	A, B string // The fields of the builders. A missing element is empty
+}
+
+// String returns the difference as Path: A != B
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %q != %q", d.Path, d.A, d.B)
+}
+
+// Diff reports the differences between two builders in their columns, values, filters, order, group
+// and options. Builders without differences build the same query. The filter functions are compared
+// only by whether they are set.
+func Diff(a, b *QueryBuilder) []Difference {
+	var diffs []Difference
+	add := func(path, x, y string) {
+		if x != y {
+			diffs = append(diffs, Difference{Path: path, A: x, B: y})
+		}
+	}
+	list := func(name string, x, y []string) {
+		for i := 0; i < len(x) || i < len(y); i++ {
+			var xs, ys string
+			if i < len(x) {
+				xs = x[i]
+			}
+			if i < len(y) {
+				ys = y[i]
+			}
+			add(name+"["+strconv.Itoa(i)+"]", xs, ys)
+		}
+	}
+	list("Columns", a.diffColumns(), b.diffColumns())
+	list("Values", a.diffValues(), b.diffValues())
+	list("Filter", a.diffFilters(), b.diffFilters())
+	list("Order", a.diffOrder(), b.diffOrder())
+	list("Group", a.Group, b.Group)
+
+	// the exported options, without the lists compared above
+	va, vb := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	for i := 0; i < va.NumField(); i++ {
+		f := va.Type().Field(i)
+		if f.PkgPath != "" || f.Type.Kind() == reflect.Slice && f.Name != "UpsertKeys" && f.Name != "ReturnColumns" {
+			continue
+		}
+		x, y := va.Field(i), vb.Field(i)
+		if f.Type.Kind() == reflect.Func || f.Type.Kind() == reflect.Interface {
+			add(f.Name, strconv.FormatBool(!x.IsNil()), strconv.FormatBool(!y.IsNil()))
+			continue
+		}
+		add(f.Name, fmt.Sprint(x.Interface()), fmt.Sprint(y.Interface()))
+	}
+	add("SoftDelete", a.softDelete, b.softDelete)
+	add("Tenant", a.tenantColumn, b.tenantColumn)
+	add("Qualify", a.qualifyExpr, b.qualifyExpr)
+	add("Page", fmt.Sprint(a.pageOffset, a.pageSize), fmt.Sprint(b.pageOffset, b.pageSize))
+	add("FilterFuncs", strconv.Itoa(len(a.moreFilterFuncs)), strconv.Itoa(len(b.moreFilterFuncs)))
+	return diffs
+}
+
+func (qb *QueryBuilder) diffColumns() []string {
+	s := make([]string, len(qb.Columns))
+	for i, c := range qb.Columns {
+		s[i] = c.Name + "(" + strconv.Itoa(c.Length) + ")"
+	}
+	return s
+}
+
+func (qb *QueryBuilder) diffValues() []string {
+	s := make([]string, len(qb.Values))
+	for i, v := range qb.Values {
+		s[i] = fmt.Sprintf("%s = %#v", v.column, realValue(v.value))
+		if !v.sqlstring {
+			s[i] += " (raw)"
+		}
+	}
+	return s
+}
+
+// diffFilters describes the filters as they are serialized by MarshalJSON
+func (qb *QueryBuilder) diffFilters() []string {
+	s := make([]string, len(qb.Filter))
+	for i, f := range qb.Filter {
+		jf := f.toJSON()
+		data, err := json.Marshal(jf)
+		if err != nil {
+			s[i] = fmt.Sprintf("%+v", jf)
+			continue
+		}
+		s[i] = string(data)
+	}
+	return s
+}
+
+func (qb *QueryBuilder) diffOrder() []string {
+	s := make([]string, len(qb.Order))
+	for i, o := range qb.Order {
+		s[i] = o.column + " ASC"
+		if o.order == DESC {
+			s[i] = o.column + " DESC"
+		}
+	}
+	return s
+}
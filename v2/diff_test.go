@@ -0,0 +1,38 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := New(WithTableName("Users"), WithDialect(POSTGRES))
+	a.AddColumn("UserName").AddColumn("Age")
+	a.AddFilter("Status", "A")
+	a.AddOrder("UserName", ASC)
+	b := New(WithTableName("Users"), WithDialect(POSTGRES))
+	b.AddColumn("UserName").AddColumn("Age")
+	b.AddFilter("Status", "A")
+	b.AddOrder("UserName", ASC)
+	if d := Diff(a, b); len(d) != 0 {
+		t.Errorf("unexpected differences: %v", d)
+	}
+
+	b.AddColumn("Email")
+	b.Filter = nil
+	b.AddFilter("Status", "B")
+	b.AddOrder("Age", DESC)
+	b.RequireWhere = true
+	b.FilterFunc = func(offset int, char string, inSeq bool) ([]string, []interface{}) { return nil, nil }
+	want := []Difference{
+		{"Columns[2]", "", "Email(255)"},
+		{"Values[2]", "", "Email = <nil>"},
+		{"Filter[0]", `{"column":"Status","operator":"=","value":"A"}`, `{"column":"Status","operator":"=","value":"B"}`},
+		{"Order[1]", "", "Age DESC"},
+		{"FilterFunc", "false", "true"},
+		{"RequireWhere", "false", "true"},
+	}
+	if d := Diff(a, b); !reflect.DeepEqual(d, want) {
+		t.Errorf("got %v, want %v", d, want)
+	}
+}
@@ -0,0 +1,128 @@
+package querybuilder
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// enumType is a database enum type of a column
+type enumType struct {
+	name    string          // name of the type, such as order_status
+	allowed map[string]bool // allowed values. Any value is allowed when empty
+}
+
+var enumNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// WithEnum declares that a column is of a database enum type, such as order_status. Its string values, and
+// the values of its filters, are checked against the allowed values at Build, which returns ErrInvalidValue
+// for the others. Any value is allowed when none are given. On PostgreSQL, the placeholders of the column are
+// cast to the type, such as $1::order_status, for the drivers that bind the values as text.
+func WithEnum(column, typeName string, allowed ...string) Option {
+	return func(q *QueryBuilder) error {
+		if column == "" || !enumNamePattern.MatchString(typeName) {
+			return fmt.Errorf("%w: enum type %q of column %q", ErrInvalidOption, typeName, column)
+		}
+		e := enumType{name: typeName, allowed: make(map[string]bool, len(allowed))}
+		for _, a := range allowed {
+			e.allowed[a] = true
+		}
+		// the enums are copied, so that the enums of copied builders are not changed
+		enums := make(map[string]enumType, len(q.enums)+1)
+		for k, v := range q.enums {
+			enums[k] = v
+		}
+		enums[strings.ToLower(column)] = e
+		q.enums = enums
+		return nil
+	}
+}
+
+// enumCast casts a placeholder of a column to its enum type on PostgreSQL
+func (qb *QueryBuilder) enumCast(column, ph string) string {
+	if qb.Dialect != POSTGRES || len(qb.enums) == 0 {
+		return ph
+	}
+	if e, ok := qb.enums[strings.ToLower(column)]; ok {
+		return ph + "::" + e.name
+	}
+	return ph
+}
+
+// checkEnums returns an error for the values of enum columns that are not allowed. The values must be resolved.
+func (qb *QueryBuilder) checkEnums() error {
+	if len(qb.enums) == 0 {
+		return nil
+	}
+	check := func(column string, value interface{}) error {
+		e, ok := qb.enums[strings.ToLower(column)]
+		if !ok || len(e.allowed) == 0 || isNil(value) {
+			return nil
+		}
+		if s, ok := value.(string); ok && e.allowed[s] {
+			return nil
+		}
+		return fmt.Errorf("%w: %v is not a value of %s of type %s", ErrInvalidValue, value, column, e.name)
+	}
+	for idx, v := range qb.Values {
+		if !v.sqlstring {
+			continue
+		}
+		if err := check(v.column, v.value); err != nil {
+			return err
+		}
+		for _, r := range qb.rows {
+			if err := check(v.column, rowValue(v, r, idx)); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range qb.Filter {
+		var err error
+		switch {
+		case f.tree != nil:
+			f.tree.conds(func(c Cond) {
+				for _, v := range condValues(c) {
+					if err == nil {
+						err = check(c.Column, v)
+					}
+				}
+			})
+		case f.in:
+			for _, v := range f.values {
+				if err == nil {
+					err = check(f.expression, v)
+				}
+			}
+		case !f.containsvalue && f.fulltext == nil:
+			for _, v := range condValues(Cond{Operator: f.op, Value: f.value}) {
+				if err == nil {
+					err = check(f.expression, v)
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// condValues returns the values of a condition that are compared to the column
+func condValues(c Cond) []interface{} {
+	switch {
+	case c.Operator == OpLike || c.Operator == OpILike:
+		return nil
+	case c.Operator == OpIn:
+		return inValues(c.Value)
+	case isArray(c.Value):
+		rv := reflect.ValueOf(c.Value)
+		vals := make([]interface{}, rv.Len())
+		for i := range vals {
+			vals[i] = rv.Index(i).Interface()
+		}
+		return vals
+	}
+	return []interface{}{c.Value}
+}
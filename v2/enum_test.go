@@ -0,0 +1,43 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWithEnum(t *testing.T) {
+	enum := WithEnum("Status", "order_status", "open", "paid")
+	q := New(WithTableName("Orders"), WithCommand(UPDATE), WithDialect(POSTGRES), enum)
+	q.AddValue("Status", "paid")
+	q.AddFilterIn("Status", "open", nil)
+	q.Where(Ne("status", "paid"))
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "UPDATE Orders SET Status = $1::order_status WHERE Status IN ($2::order_status, NULL) AND status <> $3::order_status;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"paid", "open", "paid"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	q = New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(MYSQL), enum)
+	q.AddValue("Status", "open")
+	q.AddRow("shipped")
+	if _, _, err = q.Build(); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("got %v, want ErrInvalidValue", err)
+	}
+	q.rows = nil
+	if s, _, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (Status) VALUES (?);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	if _, err = NewE(WithEnum("Status", "order_status; DROP TABLE x")); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("got %v, want ErrInvalidOption", err)
+	}
+}
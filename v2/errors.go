@@ -0,0 +1,63 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+)
+
+// joinedError is an error made of several errors, such as the errors of the options of a builder.
+// Like the errors of errors.Join, it matches each of its errors with errors.Is and errors.As.
+type joinedError struct {
+	errs []error
+}
+
+// joinErrors returns an error made of the errors that are not nil, or nil when there are none
+func joinErrors(errs ...error) error {
+	var e joinedError
+	for _, err := range errs {
+		if err != nil {
+			e.errs = append(e.errs, err)
+		}
+	}
+	switch len(e.errs) {
+	case 0:
+		return nil
+	case 1:
+		return e.errs[0]
+	}
+	return &e
+}
+
+// Error returns the messages of the errors, one per line
+func (e *joinedError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the errors
+func (e *joinedError) Unwrap() []error {
+	return e.errs
+}
+
+// Is reports whether any of the errors matches the target
+func (e *joinedError) Is(target error) bool {
+	for _, err := range e.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the errors that matches the target
+func (e *joinedError) As(target interface{}) bool {
+	for _, err := range e.errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package querybuilder
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestOptionErrors(t *testing.T) {
+	_, err := NewE(WithTableName(""), WithSchema(""), WithDialect(Dialect(99)))
+	if !errors.Is(err, ErrInvalidOption) || strings.Count(err.Error(), "\n") != 2 {
+		t.Errorf("got %v, want the three errors of the options", err)
+	}
+
+	q := New(WithTableName("Users"), WithSchema(""))
+	q.AddColumn("UserName")
+	if _, _, err = q.Build(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("got %v, want ErrInvalidOption", err)
+	}
+
+	errBad := errors.New("bad value option")
+	q = New(WithTableName("Users"), WithCommand(INSERT))
+	q.AddValue("UserName", "eaglebush", func(vo *ValueCompareOption) error { return errBad })
+	q.AddValue("Email", "a@b.c", MatchToNull(""))
+	if _, _, err = q.Build(); !errors.Is(err, errBad) {
+		t.Errorf("got %v, want %v", err, errBad)
+	}
+}
@@ -0,0 +1,34 @@
+package querybuilder
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Execer executes a statement. It is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Querier runs a query that returns rows. It is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// ExecContext builds the query with BuildContext and executes it
+func (qb *QueryBuilder) ExecContext(ctx context.Context, db Execer) (sql.Result, error) {
+	query, args, err := qb.BuildContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return db.ExecContext(ctx, query, args...)
+}
+
+// QueryContext builds the query with BuildContext and runs it. The caller closes the rows.
+func (qb *QueryBuilder) QueryContext(ctx context.Context, db Querier) (*sql.Rows, error) {
+	query, args, err := qb.BuildContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return db.QueryContext(ctx, query, args...)
+}
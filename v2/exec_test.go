@@ -0,0 +1,43 @@
+package querybuilder
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type recordExecer struct {
+	query string
+	args  []interface{}
+}
+
+func (r *recordExecer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	r.query = query
+	r.args = args
+	return nil, nil
+}
+
+func TestExecContext(t *testing.T) {
+	q := New(WithTableName("{Users}"), WithCommand(UPDATE))
+	q.AddValue("UserName", "eaglebush")
+	q.AddFilter("UserKey", 5)
+
+	db := &recordExecer{}
+	if _, err := q.ExecContext(context.Background(), db); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "UPDATE Users SET UserName = ? WHERE UserKey = ?;"; db.query != want {
+		t.Errorf("got %q, want %q", db.query, want)
+	}
+	if !reflect.DeepEqual(db.args, []interface{}{"eaglebush", 5}) {
+		t.Errorf("unexpected args: %v", db.args)
+	}
+
+	db = &recordExecer{}
+	q = New(WithCommand(DELETE))
+	if _, err := q.ExecContext(context.Background(), db); !errors.Is(err, ErrNoTableSpecified) || db.query != "" {
+		t.Errorf("expected ErrNoTableSpecified without executing, got %v", err)
+	}
+}
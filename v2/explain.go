@@ -0,0 +1,116 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExplainOptions are the options of Explain
+type ExplainOptions struct {
+	Analyze bool   // Runs the query to report the actual rows and times. Only SELECT commands can be analyzed
+	Buffers bool   // Reports the buffer usage of an analyzed query. PostgreSQL only
+	Format  string // Format of the plan, such as JSON. PostgreSQL, MySQL and Snowflake only
+}
+
+// Explain builds the query with the EXPLAIN syntax of the dialect, so that its plan is returned instead of its rows:
+//
+//	POSTGRES   EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) query
+//	MYSQL      EXPLAIN ANALYZE query, or EXPLAIN FORMAT=JSON query
+//	SQLSERVER  SET STATISTICS XML ON; query SET STATISTICS XML OFF;
+//	SQLITE     EXPLAIN QUERY PLAN query
+//	ORACLE     EXPLAIN PLAN FOR query
+//	SNOWFLAKE  EXPLAIN USING JSON query
+//	DUCKDB     EXPLAIN ANALYZE query
+//	GENERIC    EXPLAIN query
+//
+// SQL Server only explains analyzed queries, since SET SHOWPLAN must be the only statement of a batch.
+// The options a dialect does not have return ErrNotSupported. Like Build, it advances the ParameterOffset.
+func (qb *QueryBuilder) Explain(opts ExplainOptions) (query string, args []interface{}, err error) {
+	format := strings.ToUpper(opts.Format)
+	for _, c := range format {
+		if c < 'A' || c > 'Z' {
+			return "", nil, fmt.Errorf("%w: EXPLAIN format %q", ErrInvalidOption, opts.Format)
+		}
+	}
+	if opts.Analyze && qb.CommandType != SELECT {
+		return "", nil, fmt.Errorf("%w: EXPLAIN ANALYZE runs the %s command", ErrInvalidOption, qb.CommandType)
+	}
+	unsupported := func(option string) error {
+		return fmt.Errorf("%w: EXPLAIN %s", ErrNotSupported, option)
+	}
+	if opts.Buffers && qb.Dialect != POSTGRES {
+		return "", nil, unsupported("BUFFERS")
+	}
+	var prefix, suffix string
+	switch qb.Dialect {
+	case POSTGRES:
+		var o []string
+		if opts.Analyze {
+			o = append(o, "ANALYZE")
+		}
+		if opts.Buffers {
+			o = append(o, "BUFFERS")
+		}
+		if format != "" {
+			o = append(o, "FORMAT "+format)
+		}
+		prefix = "EXPLAIN "
+		if len(o) > 0 {
+			prefix += "(" + strings.Join(o, ", ") + ") "
+		}
+	case MYSQL:
+		switch {
+		case opts.Analyze && format != "" && format != "TREE":
+			return "", nil, unsupported("ANALYZE FORMAT=" + format)
+		case opts.Analyze:
+			prefix = "EXPLAIN ANALYZE "
+		case format != "":
+			prefix = "EXPLAIN FORMAT=" + format + " "
+		default:
+			prefix = "EXPLAIN "
+		}
+	case SQLSERVER:
+		if !opts.Analyze {
+			return "", nil, unsupported("without ANALYZE")
+		}
+		if format != "" && format != "XML" {
+			return "", nil, unsupported("FORMAT " + format)
+		}
+		prefix, suffix = "SET STATISTICS XML ON; ", " SET STATISTICS XML OFF;"
+	case SNOWFLAKE:
+		if opts.Analyze {
+			return "", nil, unsupported("ANALYZE")
+		}
+		prefix = "EXPLAIN "
+		if format != "" {
+			prefix += "USING " + format + " "
+		}
+	case DUCKDB:
+		if format != "" {
+			return "", nil, unsupported("FORMAT " + format)
+		}
+		prefix = "EXPLAIN "
+		if opts.Analyze {
+			prefix += "ANALYZE "
+		}
+	case BIGQUERY:
+		return "", nil, unsupported("on BigQuery")
+	default:
+		switch {
+		case opts.Analyze:
+			return "", nil, unsupported("ANALYZE")
+		case format != "":
+			return "", nil, unsupported("FORMAT " + format)
+		case qb.Dialect == SQLITE:
+			prefix = "EXPLAIN QUERY PLAN "
+		case qb.Dialect == ORACLE:
+			prefix = "EXPLAIN PLAN FOR "
+		default:
+			prefix = "EXPLAIN "
+		}
+	}
+	if query, args, err = qb.Build(); err != nil {
+		return "", nil, err
+	}
+	return prefix + query + suffix, args, nil
+}
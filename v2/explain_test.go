@@ -0,0 +1,51 @@
+package querybuilder
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		opts    ExplainOptions
+		want    string
+	}{
+		{POSTGRES, ExplainOptions{}, "EXPLAIN SELECT UserName FROM Users WHERE UserKey = $1;"},
+		{POSTGRES, ExplainOptions{Analyze: true, Buffers: true, Format: "json"}, "EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) SELECT UserName FROM Users WHERE UserKey = $1;"},
+		{MYSQL, ExplainOptions{Format: "JSON"}, "EXPLAIN FORMAT=JSON SELECT UserName FROM Users WHERE UserKey = ?;"},
+		{MYSQL, ExplainOptions{Analyze: true}, "EXPLAIN ANALYZE SELECT UserName FROM Users WHERE UserKey = ?;"},
+		{SQLSERVER, ExplainOptions{Analyze: true}, "SET STATISTICS XML ON; SELECT UserName FROM Users WHERE UserKey = @p1; SET STATISTICS XML OFF;"},
+		{SQLITE, ExplainOptions{}, "EXPLAIN QUERY PLAN SELECT UserName FROM Users WHERE UserKey = ?;"},
+		{ORACLE, ExplainOptions{}, "EXPLAIN PLAN FOR SELECT UserName FROM Users WHERE UserKey = :1"},
+		{SNOWFLAKE, ExplainOptions{Format: "json"}, "EXPLAIN USING JSON SELECT UserName FROM Users WHERE UserKey = ?;"},
+		{DUCKDB, ExplainOptions{Analyze: true}, "EXPLAIN ANALYZE SELECT UserName FROM Users WHERE UserKey = ?;"},
+	}
+	for _, tt := range tests {
+		q := New(WithTableName("Users"), WithDialect(tt.dialect))
+		q.AddColumn("UserName")
+		q.AddFilter("UserKey", 5)
+		s, args, err := q.Explain(tt.opts)
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if s != tt.want || len(args) != 1 {
+			t.Errorf("%s: got %q, want %q", tt.dialect, s, tt.want)
+		}
+	}
+
+	q := New(WithTableName("Users"), WithDialect(SQLSERVER))
+	q.AddColumn("UserName")
+	if _, _, err := q.Explain(ExplainOptions{}); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+	q = New(WithTableName("Users"), WithDialect(SQLITE))
+	q.AddColumn("UserName")
+	if _, _, err := q.Explain(ExplainOptions{Analyze: true}); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+	q = New(WithTableName("Users"), WithCommand(DELETE), WithDialect(POSTGRES))
+	if _, _, err := q.Explain(ExplainOptions{Analyze: true}); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+}
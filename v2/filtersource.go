@@ -0,0 +1,100 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FilterSource is an outside provider of filters, such as the Filter of filterbuilder. BuildFunc returns the filters
+// and their arguments, with the placeholders numbered from the offset in the style of the builder, like a FilterFunc.
+type FilterSource interface {
+	BuildFunc(offset int, char string, inSeq bool) ([]string, []interface{})
+}
+
+// WithFilter adds the filters of an outside provider, such as the Filter of filterbuilder, to the WHERE clause:
+//
+//	q := New(WithTableName("Users"), WithDialect(POSTGRES), WithFilter(&fbv))
+//
+// The builder passes the ParameterOffset, the placeholder character and the sequence flag to the provider,
+// calls it once per build and appends its arguments to those of the builder, so it needs no manual wiring.
+// The filters are added like those of AddFilterFunc.
+func WithFilter(src FilterSource) Option {
+	return func(q *QueryBuilder) error {
+		if src == nil {
+			return fmt.Errorf("%w: nil filter source", ErrInvalidOption)
+		}
+		q.AddFilterFunc(src.BuildFunc)
+		return nil
+	}
+}
+
+// WithFilterGroup adds the filters of an outside provider like WithFilter, joined by the logic in parentheses,
+// like those of AddFilterFuncGroup
+func WithFilterGroup(logic Logic, src FilterSource) Option {
+	return func(q *QueryBuilder) error {
+		if src == nil {
+			return fmt.Errorf("%w: nil filter source", ErrInvalidOption)
+		}
+		f, err := groupFilterFunc(logic, src.BuildFunc)
+		if err != nil {
+			return err
+		}
+		q.AddFilterFunc(f)
+		return nil
+	}
+}
+
+// AddFilterFuncGroup adds a filter function like AddFilterFunc, with its filters joined by the logic in parentheses
+// instead of AND, so that an "any of" block of filterbuilder is rendered as (a = ? OR b = ?). LogicNot negates the
+// filters joined by AND, like NotGroup. The group itself is joined with the other filters by AND, so that it cannot
+// widen the scopes, the tenant or the soft delete filters of the builder. An unknown logic is returned as
+// ErrInvalidOperator by Build.
+func (qb *QueryBuilder) AddFilterFuncGroup(logic Logic, f FilterFunc) *QueryBuilder {
+	if f == nil {
+		return qb
+	}
+	g, err := groupFilterFunc(logic, f)
+	if err != nil {
+		qb.err = joinErrors(qb.err, err)
+		return qb
+	}
+	return qb.AddFilterFunc(g)
+}
+
+// groupFilterFunc returns a filter function that joins the filters of f by the logic
+func groupFilterFunc(logic Logic, f FilterFunc) (FilterFunc, error) {
+	switch logic {
+	case LogicAnd, LogicOr, LogicNot:
+	default:
+		return nil, fmt.Errorf("%w: logic %q", ErrInvalidOperator, logic)
+	}
+	return func(offset int, char string, inSeq bool) ([]string, []interface{}) {
+		fbs, fa := f(offset, char, inSeq)
+		if len(fbs) == 0 {
+			return fbs, fa
+		}
+		return []string{groupFilters(logic, fbs)}, fa
+	}, nil
+}
+
+// groupFilters joins filters by the logic. The filters that have logical operators of their own are enclosed in parentheses.
+func groupFilters(logic Logic, filters []string) string {
+	if logic == LogicNot {
+		if len(filters) == 1 {
+			return "NOT (" + filters[0] + ")"
+		}
+		return "NOT " + groupFilters(LogicAnd, filters)
+	}
+	if len(filters) == 1 {
+		return filters[0]
+	}
+	parts := make([]string, len(filters))
+	for i, s := range filters {
+		u := strings.ToUpper(s)
+		if strings.Contains(u, " OR ") || strings.Contains(u, " AND ") {
+			s = "(" + s + ")"
+		}
+		parts[i] = s
+	}
+	return "(" + strings.Join(parts, " "+string(logic)+" ") + ")"
+}
@@ -0,0 +1,97 @@
+package querybuilder
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// pairFilter is a filter source like the Filter of filterbuilder
+type pairFilter struct {
+	column string
+	value  interface{}
+	calls  int
+}
+
+func (f *pairFilter) BuildFunc(offset int, char string, inSeq bool) ([]string, []interface{}) {
+	f.calls++
+	ph := char
+	if inSeq {
+		ph = fmt.Sprintf("%s%d", char, offset+1)
+	}
+	return []string{f.column + " = " + ph}, []interface{}{f.value}
+}
+
+func TestWithFilter(t *testing.T) {
+	src := &pairFilter{column: "Region", value: "EU"}
+	q := New(WithTableName("Users"), WithDialect(SQLSERVER), WithFilter(src))
+	q.AddColumn("UserName")
+	q.AddFilter("Active", true)
+	q.AddFilter("Status", "A")
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users WHERE Active = @p1 AND Status = @p2 AND Region = @p3;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{true, "A", "EU"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+	if src.calls != 1 {
+		t.Errorf("BuildFunc called %d times", src.calls)
+	}
+
+	q = New(WithTableName("Users"), WithFilter(src))
+	q.AddColumn("UserName")
+	if s, _, _ = q.Build(); s != "SELECT UserName FROM Users WHERE Region = ?;" {
+		t.Errorf("got %q", s)
+	}
+
+	if _, err := NewE(WithTableName("Users"), WithFilter(nil)); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("got %v, want %v", err, ErrInvalidOption)
+	}
+}
+
+func TestAddFilterFuncGroup(t *testing.T) {
+	anyOf := func(offset int, char string, inSeq bool) ([]string, []interface{}) {
+		return []string{
+			fmt.Sprintf("Region = %s%d", char, offset+1),
+			fmt.Sprintf("Country = %s%d AND Vip = %s%d", char, offset+2, char, offset+3),
+		}, []interface{}{"EU", "PH", true}
+	}
+	q := New(WithTableName("Users"), WithDialect(POSTGRES))
+	q.AddColumn("UserName")
+	q.AddFilter("Active", true)
+	q.AddFilterFuncGroup(LogicOr, anyOf)
+	q.AddFilterFuncGroup(LogicNot, anyOf)
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT UserName FROM Users WHERE Active = $1 AND (Region = $2 OR (Country = $3 AND Vip = $4)) " +
+		"AND NOT (Region = $5 AND (Country = $6 AND Vip = $7));"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{true, "EU", "PH", true, "EU", "PH", true}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	src := &pairFilter{column: "Region", value: "EU"}
+	q = New(WithTableName("Users"), WithFilterGroup(LogicOr, src))
+	q.AddColumn("UserName")
+	if s, _, _ = q.Build(); s != "SELECT UserName FROM Users WHERE Region = ?;" {
+		t.Errorf("got %q", s)
+	}
+
+	q = New(WithTableName("Users"))
+	q.AddColumn("UserName")
+	q.AddFilterFuncGroup("XOR", anyOf)
+	if _, _, err := q.Build(); !errors.Is(err, ErrInvalidOperator) {
+		t.Errorf("got %v, want %v", err, ErrInvalidOperator)
+	}
+}
@@ -0,0 +1,97 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+)
+
+var (
+	valueLists = regexp.MustCompile(`\?(?:\s*,\s*\?)+`)
+	rowLists   = regexp.MustCompile(`\(\?\)(?:\s*,\s*\(\?\))+`)
+)
+
+// Fingerprint returns a hash of the shape of the query, for grouping queries in metrics, plan caches and
+// slow query logs. The query is normalized before hashing: string and numeric literals and placeholders
+// become ?, lists of them are reduced to one, the whitespace is compacted and the comments are left out.
+// Queries that differ only in their values, the number of rows or IN values, or the parameter offset
+// share a fingerprint.
+func (qb *QueryBuilder) Fingerprint() (string, error) {
+	comment, tags, tagsFunc := qb.commentFunc, qb.tags, qb.tagsFunc
+	qb.commentFunc, qb.tags, qb.tagsFunc = nil, nil, nil
+	query, _, _, err := qb.buildAt(context.Background(), 0)
+	qb.commentFunc, qb.tags, qb.tagsFunc = comment, tags, tagsFunc
+	if err != nil {
+		return "", err
+	}
+	h := fnv.New64a()
+	h.Write([]byte(qb.normalize(query)))
+	return fmt.Sprintf("%016x", h.Sum64()), nil
+}
+
+// normalize replaces the literals and placeholders of a query with ? and compacts the whitespace
+func (qb *QueryBuilder) normalize(query string) string {
+	pchar, enclosing := qb.ParameterChar, qb.StringEnclosingChar
+	var sb strings.Builder
+	space := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		if c == ' ' || c == '\r' || c == '\n' || c == '\t' {
+			space = true
+			continue
+		}
+		if space {
+			sb.WriteByte(' ')
+			space = false
+		}
+		// a string literal, with its doubled enclosing characters, becomes a single ?
+		if enclosing != "" && strings.HasPrefix(query[i:], enclosing) {
+			j := i + len(enclosing)
+			for j < len(query) {
+				if strings.HasPrefix(query[j:], enclosing+enclosing) {
+					j += 2 * len(enclosing)
+					continue
+				}
+				if strings.HasPrefix(query[j:], enclosing) {
+					break
+				}
+				j++
+			}
+			sb.WriteByte('?')
+			i = j + len(enclosing) - 1
+			continue
+		}
+		switch {
+		case pchar != "" && strings.HasPrefix(query[i:], pchar):
+			j := i + len(pchar)
+			if qb.ParameterInSequence {
+				for j < len(query) && isDigit(query[j]) {
+					j++
+				}
+			}
+			sb.WriteByte('?')
+			i = j - 1
+		case isDigit(c) && (i == 0 || !isWordChar(query[i-1])):
+			j := i
+			for j < len(query) && (isDigit(query[j]) || query[j] == '.') {
+				j++
+			}
+			sb.WriteByte('?')
+			i = j - 1
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	s := valueLists.ReplaceAllString(sb.String(), "?")
+	return rowLists.ReplaceAllString(s, "(?)")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
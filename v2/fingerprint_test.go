@@ -0,0 +1,34 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	fp := func(key interface{}, active string, in ...interface{}) string {
+		q := New(WithTableName("Users"), WithDialect(POSTGRES))
+		q.AddColumn("UserName")
+		q.AddFilter("UserKey", key)
+		q.AddFilterExp("Active = '" + active + "'")
+		q.AddFilterIn("GroupKey", in...)
+		q.ParameterOffset = len(in)
+		s, err := q.Fingerprint()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		return s
+	}
+	a := fp(1, "Y", 1, 2)
+	if b := fp(2, "N", 1, 2, 3); a != b {
+		t.Errorf("fingerprints differ: %s, %s", a, b)
+	}
+	if b := fp(nil, "Y", 1, 2); a == b {
+		t.Errorf("fingerprints of different shapes are equal: %s", a)
+	}
+
+	q := New(WithTableName("Users"), WithDialect(POSTGRES))
+	if got, want := q.normalize("SELECT TOP 10 Name \rFROM Users\r\t WHERE Code = 'a''b' AND Key IN ($3, $4) AND Col2 = $5;"),
+		"SELECT TOP ? Name FROM Users WHERE Code = ? AND Key IN (?) AND Col2 = ?;"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
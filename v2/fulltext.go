@@ -0,0 +1,59 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AddFilterFullText adds a full-text search of a phrase over columns, rendered for the dialect:
+//
+//	POSTGRES   to_tsvector(concat_ws(' ', a, b)) @@ plainto_tsquery(?)
+//	SQLSERVER  FREETEXT((a, b), ?)
+//	MYSQL      MATCH (a, b) AGAINST (? IN NATURAL LANGUAGE MODE)
+//
+// The columns must have a full-text index on SQL Server and MySQL. Other dialects return ErrNotSupported
+// from Build. Searches with the CONTAINS syntax of SQL Server can be added with AddFilterExp.
+func (qb *QueryBuilder) AddFilterFullText(columns []string, phrase string) *QueryBuilder {
+	qb.touch()
+	qb.Filter = append(qb.Filter, queryFilter{
+		expression: strings.Join(columns, ", "),
+		value:      phrase,
+		fulltext:   append([]string(nil), columns...),
+	})
+	return qb
+}
+
+// checkFullText returns an error when the dialect has no full-text search
+func (qb *QueryBuilder) checkFullText() error {
+	for _, f := range qb.Filter {
+		if f.fulltext == nil {
+			continue
+		}
+		switch qb.Dialect {
+		case POSTGRES, SQLSERVER, MYSQL:
+		default:
+			return fmt.Errorf("%w: full-text search", ErrNotSupported)
+		}
+		if len(f.fulltext) == 0 {
+			return fmt.Errorf("%w: full-text search without columns", ErrNotSupported)
+		}
+	}
+	return nil
+}
+
+// fullTextClause renders a full-text search filter
+func (qb *QueryBuilder) fullTextClause(f queryFilter, paramcnt, phcnt *int) string {
+	ph := qb.placeholder(paramcnt)
+	*phcnt++
+	cols := strings.Join(f.fulltext, ", ")
+	switch qb.Dialect {
+	case POSTGRES:
+		if len(f.fulltext) > 1 {
+			cols = "concat_ws(' ', " + cols + ")"
+		}
+		return "to_tsvector(" + cols + ") @@ plainto_tsquery(" + ph + ")"
+	case SQLSERVER:
+		return "FREETEXT((" + cols + "), " + ph + ")"
+	}
+	return "MATCH (" + cols + ") AGAINST (" + ph + " IN NATURAL LANGUAGE MODE)"
+}
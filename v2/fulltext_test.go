@@ -0,0 +1,40 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAddFilterFullText(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{POSTGRES, "SELECT Title FROM Articles WHERE to_tsvector(concat_ws(' ', Title, Body)) @@ plainto_tsquery($1);"},
+		{SQLSERVER, "SELECT Title FROM Articles WHERE FREETEXT((Title, Body), @p1);"},
+		{MYSQL, "SELECT Title FROM Articles WHERE MATCH (Title, Body) AGAINST (? IN NATURAL LANGUAGE MODE);"},
+	}
+	for _, tt := range tests {
+		q := New(WithTableName("Articles"), WithDialect(tt.dialect))
+		q.AddColumn("Title")
+		q.AddFilterFullText([]string{"Title", "Body"}, "query builder")
+		s, v, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if s != tt.want {
+			t.Errorf("got %q, want %q", s, tt.want)
+		}
+		if !reflect.DeepEqual(v, []interface{}{"query builder"}) {
+			t.Errorf("unexpected args: %v", v)
+		}
+	}
+
+	q := New(WithTableName("Articles"), WithDialect(SQLITE))
+	q.AddColumn("Title")
+	q.AddFilterFullText([]string{"Title"}, "query")
+	if _, _, err := q.Build(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("got %v, want %v", err, ErrNotSupported)
+	}
+}
@@ -0,0 +1,33 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+)
+
+// Hash returns a hash of the structure of the builder, for deduplication layers and caches: the table, the command,
+// the settings, the columns, the filters, the order and the other clauses. The bound values are left out, but
+// whether a value is NULL, and the raw values rendered in the query, are part of the structure. Builders with
+// the same hash build the same query. Unlike Fingerprint, the query is not built, and the number of IN values
+// and rows counts. Filter functions, comments and tags are left out.
+func (qb *QueryBuilder) Hash() string {
+	h := fnv.New64a()
+	h.Write([]byte(qb.structure()))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// Equal reports whether two builders have the same structure, as compared by Hash
+func Equal(a, b *QueryBuilder) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.structure() == b.structure()
+}
+
+// structure returns the shape of the query of the builder with the resolved values
+func (qb *QueryBuilder) structure() string {
+	ctx := context.Background()
+	w := qb.resolved(ctx)
+	return w.shapeKey(w.schemaName(ctx)) + fmt.Sprintf("t%q|%t", w.tenantColumn, w.allTenants)
+}
@@ -0,0 +1,31 @@
+package querybuilder
+
+import "testing"
+
+func TestHash(t *testing.T) {
+	users := func(key interface{}, status string) *QueryBuilder {
+		q := New(WithTableName("Users"), WithDialect(POSTGRES))
+		q.AddColumn("UserName")
+		q.AddFilter("UserKey", key)
+		q.AddFilterIn("Status", status, "X")
+		return q
+	}
+	a, b := users(5, "A"), users(7, "B")
+	if !Equal(a, b) || a.Hash() != b.Hash() {
+		t.Errorf("builders with different values are not equal: %s %s", a.Hash(), b.Hash())
+	}
+
+	for name, c := range map[string]*QueryBuilder{
+		"nil value": users(nil, "A"),
+		"order":     users(5, "A").AddOrder("UserName", ASC),
+		"in values": users(5, "A").AddFilterIn("GroupKey", 1, 2),
+		"column":    users(5, "A").AddColumn("Email"),
+	} {
+		if Equal(a, c) || a.Hash() == c.Hash() {
+			t.Errorf("%s: builders are equal", name)
+		}
+	}
+	if Equal(a, nil) || !Equal(nil, nil) {
+		t.Errorf("unexpected equality of nil builders")
+	}
+}
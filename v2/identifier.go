@@ -0,0 +1,132 @@
+package querybuilder
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// IdentifierValidator checks a table, column, order, group or sequence name. The kind is one of
+// "table", "column", "order", "group" or "sequence". It returns an error when the name is not allowed.
+type IdentifierValidator func(kind, name string) error
+
+// ValidateIdentifiers checks the table, column, filter, order and group names of a query builder during Build,
+// along with the upsert keys and the returned columns. Expressions added with AddFilterExp are not checked.
+// When the validator is nil, SafeIdentifier is used.
+func ValidateIdentifiers(v IdentifierValidator) Option {
+	return func(q *QueryBuilder) error {
+		if v == nil {
+			v = SafeIdentifier
+		}
+		q.validator = v
+		return nil
+	}
+}
+
+var (
+	identPart = `(?:\{[a-zA-Z0-9\[\]\"\_\-\.]+\}|[a-zA-Z_][a-zA-Z0-9_$]*|"[^"]+"|\[[^\]]+\]|` + "`[^`]+`" + `)`
+	reIdent   = regexp.MustCompile(`^` + identPart + `(?:\.` + identPart + `)*(?:\.\*)?$`)
+	reTable   = regexp.MustCompile(`^` + identPart + `(?:\.` + identPart + `)*(?:\s+(?:(?i:AS)\s+)?[a-zA-Z_][a-zA-Z0-9_]*)?$`)
+)
+
+// SafeIdentifier allows names made of plain, quoted or braced identifiers separated by dots,
+// such as Orders, {Orders}, dbo.[Order Details] or o.status. Columns can be * or end with .*,
+// and tables can be followed by an alias.
+func SafeIdentifier(kind, name string) error {
+	switch {
+	case kind == "table" && reTable.MatchString(name):
+		return nil
+	case kind != "table" && (name == "*" || reIdent.MatchString(name)):
+		return nil
+	}
+	return fmt.Errorf("%w: %s %q", ErrInvalidIdentifier, kind, name)
+}
+
+// validateIdentifiers checks the names of the builder with its validator
+func (qb *QueryBuilder) validateIdentifiers() error {
+	if qb.validator == nil {
+		return nil
+	}
+	table := qb.TableName
+	if qb.TableAlias != "" {
+		table += " " + qb.TableAlias
+	}
+	if err := qb.validator("table", table); err != nil {
+		return err
+	}
+	for _, c := range qb.sourceColumns {
+		if err := qb.validator("column", c); err != nil {
+			return err
+		}
+	}
+	for _, c := range qb.Columns {
+		if err := qb.validator("column", c.Name); err != nil {
+			return err
+		}
+	}
+	for _, v := range qb.Values {
+		if v.sequence == "" {
+			continue
+		}
+		if err := qb.validator("sequence", v.sequence); err != nil {
+			return err
+		}
+	}
+	for _, f := range qb.Filter {
+		var err error
+		if f.tree != nil {
+			f.tree.conds(func(c Cond) {
+				if err == nil {
+					err = qb.validator("column", c.Column)
+				}
+			})
+		} else if f.fulltext != nil {
+			for _, c := range f.fulltext {
+				if err == nil {
+					err = qb.validator("column", c)
+				}
+			}
+		} else if !f.containsvalue {
+			// Only AddFilterExp takes an expression. Every other filter names a column
+			err = qb.validator("column", f.expression)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, f := range qb.scopes {
+		if err := qb.validator("column", f.expression); err != nil {
+			return err
+		}
+	}
+	if qb.tenantColumn != "" {
+		if err := qb.validator("column", qb.tenantColumn); err != nil {
+			return err
+		}
+	}
+	if qb.softDelete != "" {
+		if err := qb.validator("column", qb.softDelete); err != nil {
+			return err
+		}
+	}
+	for _, k := range qb.UpsertKeys {
+		if err := qb.validator("column", k); err != nil {
+			return err
+		}
+	}
+	for _, c := range qb.ReturnColumns {
+		if err := qb.validator("column", c); err != nil {
+			return err
+		}
+	}
+	for _, o := range qb.Order {
+		if err := qb.validator("order", o.column); err != nil {
+			return err
+		}
+	}
+	for _, g := range qb.Group {
+		if err := qb.validator("group", g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package querybuilder
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSafeIdentifier(t *testing.T) {
+	tests := []struct {
+		kind string
+		name string
+		ok   bool
+	}{
+		{"table", "{Orders} o", true},
+		{"table", "dbo.[Order Details]", true},
+		{"table", "Orders; DROP TABLE Users", false},
+		{"column", "o.status", true},
+		{"column", `"Order Date"`, true},
+		{"column", "o.*", true},
+		{"column", "status--", false},
+		{"order", "OrderDate DESC", false},
+		{"group", "CustomerKey", true},
+	}
+	for _, tt := range tests {
+		err := SafeIdentifier(tt.kind, tt.name)
+		if (err == nil) != tt.ok {
+			t.Errorf("SafeIdentifier(%s, %q) = %v", tt.kind, tt.name, err)
+		}
+	}
+
+	q := New(WithTableName("{Users}"), ValidateIdentifiers(nil))
+	q.AddColumn("UserName")
+	q.AddOrder("UserName); DELETE FROM Users --", ASC)
+	if _, _, err := q.Build(); !errors.Is(err, ErrInvalidIdentifier) {
+		t.Errorf("expected ErrInvalidIdentifier, got %v", err)
+	}
+}
+
+func TestValidateIdentifiersFilters(t *testing.T) {
+	tests := []struct {
+		name string
+		add  func(q *QueryBuilder)
+		ok   bool
+	}{
+		{"filter", func(q *QueryBuilder) { q.AddFilter("1=1 OR id", 5) }, false},
+		{"filter in", func(q *QueryBuilder) { q.AddFilterIn("x; DROP", 1, 2) }, false},
+		{"filters map", func(q *QueryBuilder) { q.AddFiltersMap(map[string]interface{}{"x; DROP": 1}) }, false},
+		{"null filter", func(q *QueryBuilder) { q.AddFilter("x; DROP", nil) }, false},
+		{"upsert key", func(q *QueryBuilder) { q.Upsert("id) DO NOTHING; --") }, false},
+		{"returning", func(q *QueryBuilder) { q.Returning("id; DROP TABLE Users") }, false},
+		{"columns", func(q *QueryBuilder) {
+			q.AddFilter("o.UserKey", 5).AddFilterIn("Status", "A").Upsert("UserKey").Returning("UserKey")
+		}, true},
+		{"expression", func(q *QueryBuilder) { q.AddFilterExp("Active = 1 OR Admin = 1") }, true},
+	}
+	for _, tt := range tests {
+		q := New(WithTableName("{Users}"), WithCommand(INSERT), WithDialect(POSTGRES), ValidateIdentifiers(nil))
+		q.AddValue("UserKey", 5)
+		tt.add(q)
+		_, _, err := q.Build()
+		if tt.ok && errors.Is(err, ErrInvalidIdentifier) || !tt.ok && !errors.Is(err, ErrInvalidIdentifier) {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+	}
+}
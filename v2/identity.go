@@ -0,0 +1,28 @@
+package querybuilder
+
+import (
+	"strings"
+)
+
+// WithIdentityInsert sets the identity column of the table. On SQL Server, an INSERT command that writes
+// an explicit value to the column is sent as a batch between SET IDENTITY_INSERT table ON and OFF.
+// Other dialects render the INSERT command as is.
+func WithIdentityInsert(column string) Option {
+	return func(q *QueryBuilder) error {
+		q.IdentityColumn = column
+		return nil
+	}
+}
+
+// identityInsert reports whether the INSERT command writes a value to the identity column on SQL Server
+func (qb *QueryBuilder) identityInsert() bool {
+	if qb.IdentityColumn == "" || qb.Dialect != SQLSERVER || qb.CommandType != INSERT {
+		return false
+	}
+	for _, v := range qb.Values {
+		if strings.EqualFold(v.column, qb.IdentityColumn) {
+			return !v.skip && !v.null
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package querybuilder
+
+import (
+	"testing"
+)
+
+func TestIdentityInsert(t *testing.T) {
+	q := New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(SQLSERVER), WithIdentityInsert("OrderKey"))
+	q.AddValue("OrderKey", 1001)
+	q.AddValue("Customer", "ACME")
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SET IDENTITY_INSERT Orders ON; INSERT INTO Orders (OrderKey, Customer) VALUES (@p1,@p2); SET IDENTITY_INSERT Orders OFF;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if len(v) != 2 {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	// without an identity value, the INSERT command is not wrapped
+	q = New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(SQLSERVER), WithIdentityInsert("OrderKey"))
+	q.SkipNilWriteColumn = true
+	q.AddValue("OrderKey", nil)
+	q.AddValue("Customer", "ACME")
+	if s, _, _ = q.Build(); s != "INSERT INTO Orders (Customer) VALUES (@p1);" {
+		t.Errorf("unexpected query: %q", s)
+	}
+}
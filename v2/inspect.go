@@ -0,0 +1,76 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InspectExpressions checks the raw expressions of a builder during Build for signs of injection:
+// unbalanced quotes, stacked statements and comment tokens. The table name, the expressions
+// of AddFilterExp and the Qualify expression are checked. With WarnLog, the findings are
+// written to the logger. With WarnError, Build returns ErrSuspiciousExpression. WarnSilent turns the inspection off.
+func InspectExpressions(w Warnings) Option {
+	return func(q *QueryBuilder) error {
+		q.inspect = w != WarnSilent
+		q.inspectMode = w
+		return nil
+	}
+}
+
+// inspectExpressions reports the first suspicious raw expression of the builder
+func (qb *QueryBuilder) inspectExpressions() error {
+	if !qb.inspect {
+		return nil
+	}
+	exprs := []string{qb.TableName, qb.qualifyExpr}
+	for _, f := range qb.Filter {
+		if f.containsvalue {
+			exprs = append(exprs, f.expression)
+		}
+	}
+	for _, e := range exprs {
+		reason := suspicious(e, qb.StringEnclosingChar, qb.StringEscapeChar)
+		if reason == "" {
+			continue
+		}
+		if qb.inspectMode == WarnError {
+			return fmt.Errorf("%w: %s in %q", ErrSuspiciousExpression, reason, e)
+		}
+		qb.getLogger().Warn("suspicious expression", "reason", reason, "expression", e)
+	}
+	return nil
+}
+
+// suspicious returns the reason an expression looks like an injection, or an empty string
+func suspicious(expr, enclosing, escape string) string {
+	if enclosing == "" {
+		enclosing = "'"
+	}
+	inStr := false
+	for i := 0; i < len(expr); i++ {
+		if inStr {
+			if escape != "" && escape != enclosing && strings.HasPrefix(expr[i:], escape) {
+				i += len(escape)
+				continue
+			}
+			if strings.HasPrefix(expr[i:], enclosing) {
+				inStr = false
+				i += len(enclosing) - 1
+			}
+			continue
+		}
+		switch {
+		case strings.HasPrefix(expr[i:], enclosing):
+			inStr = true
+			i += len(enclosing) - 1
+		case expr[i] == ';':
+			return "stacked statement"
+		case strings.HasPrefix(expr[i:], "--"), strings.HasPrefix(expr[i:], "/*"), strings.HasPrefix(expr[i:], "*/"):
+			return "comment token"
+		}
+	}
+	if inStr {
+		return "unbalanced quotes"
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package querybuilder
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSuspicious(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"Status = 'A'", ""},
+		{"Name = 'O''Brien'", ""},
+		{`Name = 'O\'Brien'`, ""},
+		{"Note = 'a;b -- c'", ""},
+		{"Status = 'A'; DROP TABLE Users", "stacked statement"},
+		{"Status = 'A' -- ", "comment token"},
+		{"Status = 'A' /* x */", "comment token"},
+		{"Status = 'A", "unbalanced quotes"},
+	}
+	for _, tt := range tests {
+		if got := suspicious(tt.expr, "'", `\`); got != tt.want {
+			t.Errorf("suspicious(%q) = %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+
+	q := New(WithTableName("Users"), InspectExpressions(WarnError))
+	q.AddColumn("UserName")
+	q.AddFilterExp("UserKey = 5 OR 1=1 --")
+	if _, _, err := q.Build(); !errors.Is(err, ErrSuspiciousExpression) {
+		t.Errorf("expected ErrSuspiciousExpression, got %v", err)
+	}
+
+	l := &recordLogger{}
+	q = New(WithTableName("Users; DELETE FROM Users"), InspectExpressions(WarnLog), WithLogger(l))
+	q.AddColumn("UserName")
+	if _, _, err := q.Build(); err != nil || len(l.msgs) != 1 {
+		t.Errorf("expected a logged warning, got %v %v", err, l.msgs)
+	}
+}
@@ -0,0 +1,189 @@
+package querybuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// builderJSON is the serialized definition of a query
+type builderJSON struct {
+	Command Command       `json:"command"`
+	Table   string        `json:"table"`
+	Alias   string        `json:"alias,omitempty"`
+	Columns []QueryColumn `json:"columns,omitempty"`
+	Filters []filterJSON  `json:"filters,omitempty"`
+	Order   []sortJSON    `json:"order,omitempty"`
+	Group   []string      `json:"group,omitempty"`
+	Limit   string        `json:"limit,omitempty"`
+}
+
+// filterJSON is a serialized filter. A filter is a condition, a condition tree, a full-text search of the value or an expression.
+type filterJSON struct {
+	Cond
+	Tree       *Tree    `json:"tree,omitempty"`
+	FullText   []string `json:"fulltext,omitempty"`
+	Expression string   `json:"expression,omitempty"`
+	JSONPath   string   `json:"jsonpath,omitempty"`
+	Quantifier string   `json:"quantifier,omitempty"`
+}
+
+type sortJSON struct {
+	Column string `json:"column"`
+	Order  Sort   `json:"order"`
+}
+
+// MarshalJSON serializes the definition of the query: the command, the table and its alias, the columns,
+// the filters, the order, the group and the limit. The values, the settings and the functions of the
+// builder, such as FilterFunc, are not serialized. The filters with subqueries return ErrNotSupported.
+func (qb *QueryBuilder) MarshalJSON() ([]byte, error) {
+	b := builderJSON{
+		Command: qb.CommandType,
+		Table:   qb.TableName,
+		Alias:   qb.TableAlias,
+		Columns: qb.Columns,
+		Group:   qb.Group,
+		Limit:   qb.ResultLimit,
+	}
+	for _, f := range qb.Filter {
+		if f.sub != nil {
+			return nil, fmt.Errorf("%w: JSON of the %s subquery of %s", ErrNotSupported, f.quantifier, f.expression)
+		}
+		b.Filters = append(b.Filters, f.toJSON())
+	}
+	for _, o := range qb.Order {
+		b.Order = append(b.Order, sortJSON{Column: o.column, Order: o.order})
+	}
+	return json.Marshal(b)
+}
+
+// UnmarshalJSON replaces the definition of the query with a definition serialized by MarshalJSON.
+// The settings of the builder, such as the dialect, are kept. Numbers in the values of the filters
+// are decoded as float64.
+func (qb *QueryBuilder) UnmarshalJSON(data []byte) error {
+	var b builderJSON
+	if err := json.Unmarshal(data, &b); err != nil {
+		return err
+	}
+	if b.Table == "" {
+		return ErrNoTableSpecified
+	}
+	// the builder is left unchanged when a filter is invalid
+	for _, f := range b.Filters {
+		switch {
+		case f.Tree != nil:
+			if err := f.Tree.check(); err != nil {
+				return err
+			}
+		case f.Quantifier != "" && (f.Quantifier != "ANY" && f.Quantifier != "ALL" || !comparison(f.Operator) || f.Value == nil):
+			return fmt.Errorf("%w: %s %q on %s", ErrInvalidOperator, f.Quantifier, f.Operator, f.Column)
+		case f.JSONPath != "" && !jsonPathPattern.MatchString(f.JSONPath):
+			return fmt.Errorf("%w: JSON path %q of %s", ErrInvalidOption, f.JSONPath, f.Column)
+		case f.FullText == nil && f.Expression == "" && !f.Operator.valid():
+			return fmt.Errorf("%w: %q on %s", ErrInvalidOperator, f.Operator, f.Column)
+		}
+	}
+	qb.touch()
+	qb.CommandType = b.Command
+	qb.SourceAs(b.Table, b.Alias)
+	qb.Columns, qb.Values = nil, nil
+	for _, c := range b.Columns {
+		qb.AddColumnFixed(c.Name, c.Length)
+	}
+	qb.Filter = nil
+	for _, f := range b.Filters {
+		switch {
+		case f.Tree != nil:
+			qb.WhereTree(*f.Tree)
+		case f.FullText != nil:
+			phrase, _ := f.Value.(string)
+			qb.AddFilterFullText(f.FullText, phrase)
+		case f.Expression != "":
+			qb.AddFilterExp(f.Expression)
+		case f.JSONPath != "":
+			qb.AddFilterJSONPath(f.Column, f.JSONPath, f.Value)
+		case f.Quantifier != "":
+			qb.addQuantified(f.Quantifier, f.Column, f.Operator, f.Value)
+		default:
+			qb.Where(f.Cond)
+		}
+	}
+	qb.Order = nil
+	for _, o := range b.Order {
+		qb.AddOrder(o.Column, o.Order)
+	}
+	qb.Group = b.Group
+	qb.ResultLimit = b.Limit
+	return nil
+}
+
+// toJSON returns the serialized filter
+func (f queryFilter) toJSON() filterJSON {
+	var jf filterJSON
+	switch {
+	case f.in:
+		jf.Cond = Cond{Column: f.expression, Operator: OpIn, Value: append([]interface{}{}, f.values...), CaseInsensitive: f.ci}
+	case f.fulltext != nil:
+		jf.FullText, jf.Value = f.fulltext, f.value
+	case f.tree != nil:
+		jf.Tree = f.tree
+	case f.containsvalue:
+		jf.Expression = f.expression
+	case f.jsonPath != "":
+		jf.Cond = Cond{Column: f.expression, Operator: OpEq, Value: f.value}
+		jf.JSONPath = f.jsonPath
+	case f.quantifier != "":
+		jf.Cond = Cond{Column: f.expression, Operator: f.op, Value: f.value}
+		jf.Quantifier = f.quantifier
+	default:
+		op := f.op
+		if op == "" {
+			op = OpEq
+		}
+		jf.Cond = Cond{Column: f.expression, Operator: op, Value: f.value, CaseInsensitive: f.ci, Escaped: f.escape}
+	}
+	return jf
+}
+
+// MarshalText returns the SQL keyword of the command
+func (c Command) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText parses the SQL keyword of a command
+func (c *Command) UnmarshalText(text []byte) error {
+	switch strings.ToUpper(string(text)) {
+	case "SELECT":
+		*c = SELECT
+	case "INSERT":
+		*c = INSERT
+	case "UPDATE":
+		*c = UPDATE
+	case "DELETE":
+		*c = DELETE
+	default:
+		return fmt.Errorf("%w: command %q", ErrInvalidOption, text)
+	}
+	return nil
+}
+
+// MarshalText returns the SQL keyword of the sort order
+func (s Sort) MarshalText() ([]byte, error) {
+	if s == DESC {
+		return []byte("DESC"), nil
+	}
+	return []byte("ASC"), nil
+}
+
+// UnmarshalText parses the SQL keyword of a sort order
+func (s *Sort) UnmarshalText(text []byte) error {
+	switch strings.ToUpper(string(text)) {
+	case "ASC":
+		*s = ASC
+	case "DESC":
+		*s = DESC
+	default:
+		return fmt.Errorf("%w: sort %q", ErrInvalidOption, text)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package querybuilder
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	q := New(WithTableName("{Users}"), WithDialect(POSTGRES))
+	q.TableAlias = "u"
+	q.ResultLimit = "10"
+	q.AddColumn("UserName").AddColumnFixed("Code", 3)
+	q.AddFilter("Status", "A", CaseInsensitive())
+	q.AddFilterIn("GroupKey", 1, 2)
+	q.Where(Like("UserName", "j%"))
+	q.WhereTree(Or(Eq("Age", nil), Gt("Age", 18)))
+	q.AddFilterExp("DeletedAt IS NULL")
+	q.AddOrder("UserName", DESC)
+	q.AddGroup("UserName")
+	data, err := json.Marshal(q)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+
+	r := New(WithDialect(POSTGRES))
+	if err = json.Unmarshal(data, r); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	got, args, err := r.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if len(args) != 5 || args[1] != float64(1) {
+		t.Errorf("unexpected args: %v", args)
+	}
+
+	r = New()
+	if err = json.Unmarshal([]byte(`{"command":"MERGE","table":"Users"}`), r); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+	if err = json.Unmarshal([]byte(`{"command":"delete","table":"Users","filters":[{"column":"UserKey","operator":"=="}]}`), r); !errors.Is(err, ErrInvalidOperator) {
+		t.Errorf("expected ErrInvalidOperator, got %v", err)
+	}
+}
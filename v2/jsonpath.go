@@ -0,0 +1,83 @@
+package querybuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// jsonPathPattern matches the JSON paths of AddFilterJSONPath, such as $.status or $.items[0].id
+var jsonPathPattern = regexp.MustCompile(`^\$(\.[A-Za-z_][A-Za-z0-9_]*|\[[0-9]+\])+$`)
+
+// AsJSON marshals the value, such as a struct, a map or a json.RawMessage, to JSON when it is added,
+// and binds the JSON text. A nil value is written as NULL. An error of the marshaling is returned by Build.
+func AsJSON() ValueOption {
+	return func(vco *ValueCompareOption) error {
+		vco.JSON = true
+		return nil
+	}
+}
+
+// jsonText returns the JSON text of a value for AsJSON
+func jsonText(value interface{}) (interface{}, error) {
+	if isNil(value) {
+		return nil, nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: JSON value: %s", ErrInvalidOption, err)
+	}
+	return string(b), nil
+}
+
+// AddFilterJSONPath adds a filter that compares the value at a path of a JSON column, such as $.status
+// or $.items[0].id, or matches NULL when the value is nil. The value at the path is extracted as text:
+//
+//	POSTGRES              payload ->> 'status', or payload #>> '{items,0,id}'
+//	MYSQL                 JSON_UNQUOTE(JSON_EXTRACT(payload, '$.status'))
+//	SQLITE                json_extract(payload, '$.status')
+//	SNOWFLAKE             JSON_EXTRACT_PATH_TEXT(payload, 'status')
+//	DUCKDB                json_extract_string(payload, '$.status')
+//	SQLSERVER and others  JSON_VALUE(payload, '$.status')
+//
+// Compare the text with string values. A path that is not made of keys and indexes is returned as an error by Build.
+func (qb *QueryBuilder) AddFilterJSONPath(column, path string, value interface{}) *QueryBuilder {
+	qb.touch()
+	if !jsonPathPattern.MatchString(path) {
+		if qb.err == nil {
+			qb.err = fmt.Errorf("%w: JSON path %q of %s", ErrInvalidOption, path, column)
+		}
+		return qb
+	}
+	qb.Filter = append(qb.Filter, queryFilter{
+		expression: column,
+		value:      value,
+		jsonPath:   path,
+	})
+	return qb
+}
+
+// jsonValue renders the text at a JSON path of a column for the dialect
+func (qb *QueryBuilder) jsonValue(column, path string) string {
+	switch qb.Dialect {
+	case POSTGRES:
+		segs := strings.FieldsFunc(path[1:], func(r rune) bool { return r == '.' || r == '[' || r == ']' })
+		if len(segs) == 1 {
+			if strings.HasPrefix(path, "$[") {
+				return column + " ->> " + segs[0]
+			}
+			return column + " ->> '" + segs[0] + "'"
+		}
+		return column + " #>> '{" + strings.Join(segs, ",") + "}'"
+	case MYSQL:
+		return "JSON_UNQUOTE(JSON_EXTRACT(" + column + ", '" + path + "'))"
+	case SQLITE:
+		return "json_extract(" + column + ", '" + path + "')"
+	case SNOWFLAKE:
+		return "JSON_EXTRACT_PATH_TEXT(" + column + ", '" + strings.TrimPrefix(path[1:], ".") + "')"
+	case DUCKDB:
+		return "json_extract_string(" + column + ", '" + path + "')"
+	}
+	return "JSON_VALUE(" + column + ", '" + path + "')"
+}
@@ -0,0 +1,81 @@
+package querybuilder
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAddFilterJSONPath(t *testing.T) {
+	for _, tc := range []struct {
+		dialect Dialect
+		want    string
+	}{
+		{POSTGRES, "SELECT OrderKey FROM Orders WHERE payload ->> 'status' = $1 AND payload #>> '{items,0,sku}' = $2 AND payload ->> 'note' IS NULL;"},
+		{MYSQL, "SELECT OrderKey FROM Orders WHERE JSON_UNQUOTE(JSON_EXTRACT(payload, '$.status')) = ? AND JSON_UNQUOTE(JSON_EXTRACT(payload, '$.items[0].sku')) = ? AND JSON_UNQUOTE(JSON_EXTRACT(payload, '$.note')) IS NULL;"},
+		{SQLSERVER, "SELECT OrderKey FROM Orders WHERE JSON_VALUE(payload, '$.status') = @p1 AND JSON_VALUE(payload, '$.items[0].sku') = @p2 AND JSON_VALUE(payload, '$.note') IS NULL;"},
+		{SNOWFLAKE, "SELECT OrderKey FROM Orders WHERE JSON_EXTRACT_PATH_TEXT(payload, 'status') = ? AND JSON_EXTRACT_PATH_TEXT(payload, 'items[0].sku') = ? AND JSON_EXTRACT_PATH_TEXT(payload, 'note') IS NULL;"},
+	} {
+		q := New(WithTableName("Orders"), WithDialect(tc.dialect))
+		q.AddColumn("OrderKey")
+		q.AddFilterJSONPath("payload", "$.status", "active")
+		q.AddFilterJSONPath("payload", "$.items[0].sku", "A-1")
+		q.AddFilterJSONPath("payload", "$.note", nil)
+		s, v, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if s != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.dialect, s, tc.want)
+		}
+		if !reflect.DeepEqual(v, []interface{}{"active", "A-1"}) {
+			t.Errorf("%s: unexpected args: %v", tc.dialect, v)
+		}
+	}
+
+	q := New(WithTableName("Orders"))
+	q.AddColumn("OrderKey")
+	q.AddFilterJSONPath("payload", "$.status", "active")
+	data, err := json.Marshal(q)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	c := New()
+	if err = json.Unmarshal(data, c); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if d := Diff(q, c); len(d) != 0 {
+		t.Errorf("unexpected differences after a JSON round trip: %v", d)
+	}
+
+	q.AddFilterJSONPath("payload", "$.status' OR 1=1 --", "x")
+	if _, _, err := q.Build(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("got %v, want ErrInvalidOption", err)
+	}
+}
+
+func TestAsJSON(t *testing.T) {
+	type item struct {
+		SKU string `json:"sku"`
+	}
+	q := New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(POSTGRES))
+	q.AddValue("payload", map[string]interface{}{"items": []item{{SKU: "A-1"}}}, AsJSON())
+	q.AddValue("raw", json.RawMessage(`{"a":1}`))
+	q.AddValue("note", nil, AsJSON())
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (payload, raw, note) VALUES ($1,$2,NULL);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if want := []interface{}{`{"items":[{"sku":"A-1"}]}`, `{"a":1}`}; !reflect.DeepEqual(v, want) {
+		t.Errorf("got %v, want %v", v, want)
+	}
+
+	q.AddValue("bad", func() {}, AsJSON())
+	if _, _, err = q.Build(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("got %v, want ErrInvalidOption", err)
+	}
+}
@@ -0,0 +1,97 @@
+package querybuilder
+
+import "strings"
+
+// Layout is the whitespace layout of the rendered query
+type Layout uint8
+
+// Layout enum
+const (
+	LayoutCompact  Layout = 0 // Single line with single spaces. This is the default
+	LayoutReadable Layout = 1 // Clauses on their own lines separated by \n, and filters indented with spaces
+	LayoutLegacy   Layout = 2 // Clauses separated by \r and \t, as rendered by earlier versions
+)
+
+// WithLayout sets the whitespace layout of the rendered query
+func WithLayout(l Layout) Option {
+	return func(q *QueryBuilder) error {
+		q.Layout = l
+		return nil
+	}
+}
+
+// layout applies the layout of the builder to a query rendered with the legacy separators.
+// The whitespace in string literals is kept.
+func (qb *QueryBuilder) layout(query string) string {
+	switch {
+	case qb.CompactSQL || qb.Layout == LayoutCompact:
+		return compact(query, qb.StringEnclosingChar, qb.StringEscapeChar)
+	case qb.Layout == LayoutReadable:
+		return readable(query, qb.StringEnclosingChar, qb.StringEscapeChar)
+	}
+	return query
+}
+
+// separator returns the whitespace between the statements of a batch
+func (qb *QueryBuilder) separator() string {
+	switch {
+	case qb.CompactSQL || qb.Layout == LayoutCompact:
+		return " "
+	case qb.Layout == LayoutReadable:
+		return "\n"
+	}
+	return "\r"
+}
+
+// readable replaces the legacy separators with new lines. The AND of the filters is indented.
+func readable(query, enclosing, escape string) string {
+	var sb strings.Builder
+	inStr := false
+	for i := 0; i < len(query); i++ {
+		if n := escaped(query[i:], inStr, enclosing, escape); n > 0 {
+			sb.WriteString(query[i : i+n])
+			i += n - 1
+			continue
+		}
+		if enclosing != "" && strings.HasPrefix(query[i:], enclosing) {
+			inStr = !inStr
+		}
+		if inStr || query[i] != ' ' && query[i] != '\r' {
+			sb.WriteByte(query[i])
+			continue
+		}
+		// a separator is a \r with the optional space before and the tabs and space after
+		j := i
+		if query[j] == ' ' {
+			j++
+		}
+		if j >= len(query) || query[j] != '\r' {
+			sb.WriteByte(query[i])
+			continue
+		}
+		j++
+		tabs := 0
+		for j < len(query) && query[j] == '\t' {
+			tabs++
+			j++
+		}
+		if tabs > 0 && j < len(query) && query[j] == ' ' {
+			j++
+		}
+		sb.WriteByte('\n')
+		if tabs > 1 {
+			sb.WriteString("  ")
+		}
+		i = j - 1
+	}
+	return sb.String()
+}
+
+// escaped returns the length of the escape sequence at the start of s inside a string literal, or zero.
+// An escaped enclosing character does not end the literal.
+func escaped(s string, inStr bool, enclosing, escape string) int {
+	if !inStr || escape == "" || escape == enclosing || !strings.HasPrefix(s, escape) || len(s) == len(escape) {
+		return 0
+	}
+	return len(escape) + 1
+}
@@ -0,0 +1,53 @@
+package querybuilder
+
+import "testing"
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		layout Layout
+		want   string
+	}{
+		{LayoutCompact, "SELECT UserName FROM Users WHERE UserKey = $1 AND Notes = 'a  b';"},
+		{LayoutReadable, "SELECT UserName\nFROM Users\nWHERE UserKey = $1\n  AND Notes = 'a  b';"},
+		{LayoutLegacy, "SELECT UserName \rFROM Users\r\t WHERE UserKey = $1\r\t\t AND Notes = 'a  b';"},
+	}
+	for _, tt := range tests {
+		q := New(WithTableName("Users"), WithDialect(POSTGRES), WithLayout(tt.layout))
+		q.AddColumn("UserName")
+		q.AddFilter("UserKey", 5)
+		q.AddFilterExp("Notes = 'a  b'")
+		s, _, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if s != tt.want {
+			t.Errorf("got %q, want %q", s, tt.want)
+		}
+	}
+
+	ins := New(WithTableName("Users"), WithCommand(INSERT), WithDialect(POSTGRES), WithLayout(LayoutReadable))
+	ins.AddValue("UserName", "a")
+	del := New(WithTableName("Users"), WithCommand(DELETE), WithDialect(POSTGRES), WithLayout(LayoutReadable))
+	del.AddFilter("UserKey", 5)
+	s, _, err := NewBatch(ins, del).Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Users (UserName) VALUES ($1);\nDELETE\nFROM Users\nWHERE UserKey = $2;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
+
+func TestLayoutEscapedLiteral(t *testing.T) {
+	q := New(WithTableName("Users"))
+	q.AddColumn("UserName")
+	q.AddFilterExp(`Notes = 'it\'s  \\'`)
+	q.AddFilter("UserKey", 5)
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := `SELECT UserName FROM Users WHERE Notes = 'it\'s  \\' AND UserKey = ?;`; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
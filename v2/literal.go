@@ -0,0 +1,94 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	ssd "github.com/shopspring/decimal"
+)
+
+// BuildLiteral builds the query with the values rendered inline as SQL literals, for generated scripts
+// and admin tools. Unlike DebugSQL, the strings are escaped by the rules of the dialect, booleans are
+// rendered as TRUE and FALSE where the dialect has them, and values without a safe literal, such as
+// NaN, strings with NUL characters or structs, return ErrNotSupported. The ParameterOffset is not advanced.
+//
+// The GENERIC dialect escapes the strings with Escape.
+func (qb *QueryBuilder) BuildLiteral() (string, error) {
+	offset := qb.ParameterOffset
+	query, args, _, err := qb.buildAt(context.Background(), offset)
+	if err != nil {
+		return "", err
+	}
+	for _, a := range args {
+		if _, err := qb.sqlLiteral(a); err != nil {
+			return "", err
+		}
+	}
+	return qb.inlineArgs(query, args, offset, func(v interface{}) string {
+		lit, _ := qb.sqlLiteral(v)
+		return lit
+	}), nil
+}
+
+// sqlLiteral renders a value as an SQL literal of the dialect
+func (qb *QueryBuilder) sqlLiteral(value interface{}) (string, error) {
+	value = realValue(value)
+	switch t := value.(type) {
+	case string:
+		return qb.stringLiteral(t)
+	case bool:
+		switch qb.Dialect {
+		case POSTGRES, MYSQL, SQLITE, SNOWFLAKE, BIGQUERY, DUCKDB:
+			if t {
+				return "TRUE", nil
+			}
+			return "FALSE", nil
+		}
+	case time.Time:
+		s, _ := qb.stringLiteral(t.Format("2006-01-02 15:04:05.999999999"))
+		if qb.Dialect == ORACLE || qb.Dialect == BIGQUERY {
+			s = "TIMESTAMP " + s
+		}
+		return s, nil
+	case float32:
+		if math.IsNaN(float64(t)) || math.IsInf(float64(t), 0) {
+			return "", fmt.Errorf("%w: %v literal", ErrNotSupported, t)
+		}
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return "", fmt.Errorf("%w: %v literal", ErrNotSupported, t)
+		}
+	case nil, []byte, ssd.Decimal, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+	default:
+		// string types, such as the VarChar of datahelperlite
+		if reflect.ValueOf(value).Kind() == reflect.String {
+			return qb.stringLiteral(reflect.ValueOf(value).String())
+		}
+		if isArray(value) {
+			return qb.arrayLiteral(value)
+		}
+		return "", fmt.Errorf("%w: %T literal", ErrNotSupported, value)
+	}
+	return qb.literal(value), nil
+}
+
+// stringLiteral encloses a string in quotes, escaping it by the rules of the dialect
+func (qb *QueryBuilder) stringLiteral(s string) (string, error) {
+	if strings.IndexByte(s, 0) >= 0 {
+		return "", fmt.Errorf("%w: string literal with a NUL character", ErrNotSupported)
+	}
+	switch qb.Dialect {
+	case GENERIC:
+		return qb.StringEnclosingChar + qb.Escape(s) + qb.StringEnclosingChar, nil
+	case MYSQL, BIGQUERY:
+		// the backslash is an escape character in the strings of these dialects
+		s = strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s)
+	default:
+		s = strings.ReplaceAll(s, "'", "''")
+	}
+	return "'" + s + "'", nil
+}
@@ -0,0 +1,55 @@
+package querybuilder
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	ssd "github.com/shopspring/decimal"
+)
+
+func TestBuildLiteral(t *testing.T) {
+	at := time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)
+	credit := ssd.NewFromInt(100)
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{GENERIC, `UPDATE Users SET UserName = 'O\'Brien \x', Active = 1, Birthdate = '2001-02-03 04:05:06', Hash = X'dead', Notes = NULL, Balance = -12.5, Credit = 100 WHERE UserKey = 5;`},
+		{POSTGRES, `UPDATE Users SET UserName = 'O''Brien \x', Active = TRUE, Birthdate = '2001-02-03 04:05:06', Hash = '\xdead', Notes = NULL, Balance = -12.5, Credit = 100 WHERE UserKey = 5;`},
+		{MYSQL, `UPDATE Users SET UserName = 'O\'Brien \\x', Active = TRUE, Birthdate = '2001-02-03 04:05:06', Hash = 0xdead, Notes = NULL, Balance = -12.5, Credit = 100 WHERE UserKey = 5;`},
+		{SQLSERVER, `UPDATE Users SET UserName = 'O''Brien \x', Active = 1, Birthdate = '2001-02-03 04:05:06', Hash = 0xdead, Notes = NULL, Balance = -12.5, Credit = 100 WHERE UserKey = 5;`},
+		{ORACLE, `UPDATE Users SET UserName = 'O''Brien \x', Active = 1, Birthdate = TIMESTAMP '2001-02-03 04:05:06', Hash = HEXTORAW('dead'), Notes = NULL, Balance = -12.5, Credit = 100 WHERE UserKey = 5`},
+	}
+	for _, tt := range tests {
+		q := New(WithTableName("Users"), WithCommand(UPDATE), WithDialect(tt.dialect))
+		q.AddValue("UserName", `O'Brien \x`)
+		q.AddValue("Active", true)
+		q.AddValue("Birthdate", at)
+		q.AddValue("Hash", []byte{0xde, 0xad})
+		q.AddValue("Notes", nil)
+		q.AddValue("Balance", ssd.RequireFromString("-12.50"))
+		q.AddValue("Credit", &credit)
+		q.AddFilter("UserKey", 5)
+		s, err := q.BuildLiteral()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if s != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.dialect, s, tt.want)
+		}
+		if q.ParameterOffset != 0 {
+			t.Errorf("unexpected parameter offset %d", q.ParameterOffset)
+		}
+	}
+
+	for _, v := range []interface{}{math.NaN(), "a\x00b"} {
+		q := New(WithTableName("Users"), WithDialect(POSTGRES))
+		q.AddColumn("UserName")
+		q.AddFilter("Code", v)
+		if _, err := q.BuildLiteral(); !errors.Is(err, ErrNotSupported) {
+			t.Errorf("%v: expected ErrNotSupported, got %v", v, err)
+		}
+	}
+}
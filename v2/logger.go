@@ -0,0 +1,88 @@
+package querybuilder
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+type Warnings uint8
+
+// Warnings enum
+const (
+	WarnLog    Warnings = 0 // Warnings are written to the logger
+	WarnSilent Warnings = 1 // Warnings are discarded
+	WarnError  Warnings = 2 // Warnings are returned as errors by Build, such as ErrWarning for an empty string on Oracle
+)
+
+// Logger receives the warnings of the builder. It is implemented by *slog.Logger.
+type Logger interface {
+	Warn(msg string, args ...interface{})
+}
+
+// stdLogger writes warnings with the standard log package in the key=value form of slog
+type stdLogger struct{}
+
+func (stdLogger) Warn(msg string, args ...interface{}) {
+	var sb strings.Builder
+	sb.WriteString("querybuilder: " + msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		sb.WriteString(fmt.Sprintf(" %v=%v", args[i], args[i+1]))
+	}
+	log.Print(sb.String())
+}
+
+var (
+	loggerMu      sync.RWMutex
+	defaultLogger Logger = stdLogger{}
+)
+
+// SetLogger sets the logger of the query builders without their own logger. A nil logger restores the standard log package.
+func SetLogger(l Logger) {
+	if l == nil {
+		l = stdLogger{}
+	}
+	loggerMu.Lock()
+	defaultLogger = l
+	loggerMu.Unlock()
+}
+
+// WithLogger sets the logger of a query builder
+func WithLogger(l Logger) Option {
+	return func(q *QueryBuilder) error {
+		q.logger = l
+		return nil
+	}
+}
+
+// WithWarnings sets how the warnings of a query builder are handled. The builder only warns of the empty strings
+// of Oracle, which stores them as NULL. WarnError makes such a build fail, so it is never the default.
+func WithWarnings(w Warnings) Option {
+	return func(q *QueryBuilder) error {
+		q.Warnings = w
+		return nil
+	}
+}
+
+// warn logs, discards or returns a warning. The arguments are key and value pairs.
+func (qb *QueryBuilder) warn(msg string, args ...interface{}) error {
+	switch qb.Warnings {
+	case WarnSilent:
+		return nil
+	case WarnError:
+		return fmt.Errorf("%w: %s", ErrWarning, msg)
+	}
+	qb.getLogger().Warn(msg, args...)
+	return nil
+}
+
+// getLogger returns the logger of the builder, or the package logger
+func (qb *QueryBuilder) getLogger() Logger {
+	if qb.logger != nil {
+		return qb.logger
+	}
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	return defaultLogger
+}
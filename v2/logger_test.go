@@ -0,0 +1,70 @@
+package querybuilder
+
+import (
+	"errors"
+	"testing"
+)
+
+type recordLogger struct {
+	msgs []string
+	args []interface{}
+}
+
+func (l *recordLogger) Warn(msg string, args ...interface{}) {
+	l.msgs = append(l.msgs, msg)
+	l.args = append(l.args, args...)
+}
+
+func TestWarnings(t *testing.T) {
+	l := &recordLogger{}
+	q := New(WithTableName("Users"), WithCommand(INSERT), WithDialect(ORACLE), WithLogger(l))
+	q.AddValue("UserName", "")
+	if _, _, err := q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if len(l.msgs) != 1 || l.args[1] != "UserName" {
+		t.Errorf("unexpected warnings: %v %v", l.msgs, l.args)
+	}
+
+	l = &recordLogger{}
+	q = New(WithTableName("Users"), WithCommand(INSERT), WithDialect(ORACLE), WithLogger(l), WithWarnings(WarnSilent))
+	q.AddValue("UserName", "")
+	if _, _, err := q.Build(); err != nil || len(l.msgs) != 0 {
+		t.Errorf("expected silenced warnings, got %v %v", err, l.msgs)
+	}
+
+	q = New(WithTableName("Users"), WithCommand(INSERT), WithDialect(ORACLE), WithWarnings(WarnError))
+	q.AddValue("UserName", "")
+	if _, _, err := q.Build(); !errors.Is(err, ErrWarning) {
+		t.Errorf("expected ErrWarning, got %v", err)
+	}
+}
+
+func TestWarnError(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(q *QueryBuilder)
+		want  error
+	}{
+		{"filter", func(q *QueryBuilder) { q.AddFilter("Code", "") }, ErrWarning},
+		{"tree", func(q *QueryBuilder) { q.WhereTree(Or(Eq("Code", ""), Eq("Name", "a"))) }, ErrWarning},
+		{"value", func(q *QueryBuilder) { q.AddValue("Name", "").AddFilter("UserKey", 5) }, ErrWarning},
+		{"non-empty", func(q *QueryBuilder) { q.AddValue("Name", "a").AddFilter("Code", "b") }, nil},
+	}
+	for _, tt := range tests {
+		q := New(WithTableName("Users"), WithCommand(UPDATE), WithDialect(ORACLE), WithWarnings(WarnError))
+		q.AddValue("UserKey", 5)
+		tt.build(q)
+		if _, _, err := q.Build(); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	// the empty strings of the other dialects are values
+	q := New(WithTableName("Users"), WithCommand(UPDATE), WithDialect(POSTGRES), WithWarnings(WarnError))
+	q.AddValue("Name", "")
+	q.AddFilter("Code", "")
+	if _, _, err := q.Build(); err != nil {
+		t.Errorf("Error: %s", err)
+	}
+}
@@ -0,0 +1,92 @@
+package querybuilder
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// memoBuild is the result of the last build of a memoizing builder
+type memoBuild struct {
+	ctx     context.Context // context of the build
+	offset  int             // parameter offset before the build
+	next    int             // parameter offset after the build
+	query   string
+	args    []interface{}
+	columns []string // columns of the arguments, for CaptureArgs
+}
+
+// WithMemoize sets the condition to return the result of the previous build until the builder is changed.
+// A memoized build advances the ParameterOffset and calls the CaptureArgs function and the metrics
+// like a rendered build. As a build advances the offset of numbered placeholders, such as $1,
+// set the ParameterOffset back before building again to reuse the memoized build.
+//
+// The methods of the builder, such as AddValue and AddFilter, discard the memoized build. Assignments to the
+// fields of the builder and changes to values given as pointers are not tracked: call Invalidate after them.
+func WithMemoize(enabled bool) Option {
+	return func(q *QueryBuilder) error {
+		q.MemoizeBuild = enabled
+		q.memo = nil
+		return nil
+	}
+}
+
+// Invalidate discards the memoized build, so that the next build renders the query again
+func (qb *QueryBuilder) Invalidate() *QueryBuilder {
+	qb.memo = nil
+	return qb
+}
+
+// touch discards the memoized build after a change to the builder
+func (qb *QueryBuilder) touch() {
+	qb.memo = nil
+}
+
+// memoized returns the memoized build when the builder, the context and the parameter offset are unchanged
+func (qb *QueryBuilder) memoized(ctx context.Context) (query string, args []interface{}, ok bool) {
+	m := qb.memo
+	if !qb.MemoizeBuild || m == nil || !sameContext(m.ctx, ctx) || qb.ParameterOffset != m.offset {
+		return "", nil, false
+	}
+	start := time.Now()
+	qb.ParameterOffset = m.next
+	args = append([]interface{}(nil), m.args...)
+	for i, a := range args {
+		qb.capture(m.columns[i], a)
+	}
+	if o := qb.observer(); o != nil {
+		o.ObserveBuild(qb.CommandType, time.Since(start), len(args), nil)
+	}
+	return m.query, args, true
+}
+
+// memoizedBuild builds the query and keeps the result with the columns of its arguments
+func (qb *QueryBuilder) memoizedBuild(ctx context.Context) (query string, args []interface{}, err error) {
+	offset := qb.ParameterOffset
+	var columns []string
+	capture := qb.captureArgs
+	qb.captureArgs = func(column string, value interface{}) {
+		columns = append(columns, column)
+		if capture != nil {
+			capture(column, value)
+		}
+	}
+	query, args, err = qb.build(ctx)
+	qb.captureArgs = capture
+	if err != nil || !reflect.TypeOf(ctx).Comparable() || len(columns) != len(args) {
+		return
+	}
+	qb.memo = &memoBuild{
+		ctx:     ctx,
+		offset:  offset,
+		next:    qb.ParameterOffset,
+		query:   query,
+		args:    append([]interface{}(nil), args...),
+		columns: columns,
+	}
+	return
+}
+
+func sameContext(a, b context.Context) bool {
+	return reflect.TypeOf(b).Comparable() && a == b
+}
@@ -0,0 +1,64 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMemoizeBuild(t *testing.T) {
+	captured := 0
+	metrics := &recordMetrics{}
+	q := New(WithTableName("Users"), WithDialect(POSTGRES), WithMemoize(true), WithMetrics(metrics))
+	q.AddColumn("UserName")
+	q.AddFilter("UserKey", 5)
+	q.CaptureArgs(func(column string, value interface{}) { captured++ })
+
+	s1, v1, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	memo := q.memo
+	q.ParameterOffset = 0
+	s2, v2, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if s1 != s2 || !reflect.DeepEqual(v1, v2) {
+		t.Errorf("got %q %v, want %q %v", s2, v2, s1, v1)
+	}
+	if q.memo != memo {
+		t.Errorf("query was rendered again")
+	}
+	if captured != 2 || len(metrics.cmds) != 2 || q.ParameterOffset != 1 {
+		t.Errorf("memoized build captured %d args, observed %d builds, offset %d", captured, len(metrics.cmds), q.ParameterOffset)
+	}
+
+	// Like a build without memoization, the next build continues the placeholder sequence
+	s3, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users WHERE UserKey = $2;"; s3 != want {
+		t.Errorf("got %q, want %q", s3, want)
+	}
+
+	q.AddFilter("Active", true)
+	q.ParameterOffset = 0
+	s4, v4, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users WHERE UserKey = $1 AND Active = $2;"; s4 != want {
+		t.Errorf("got %q, want %q", s4, want)
+	}
+	if len(v4) != 2 {
+		t.Errorf("unexpected args %v", v4)
+	}
+
+	q.ResultLimit = "10"
+	q.Invalidate()
+	q.ParameterOffset = 0
+	if s5, _, _ := q.Build(); s5 == s4 {
+		t.Errorf("memoized build was not invalidated: %q", s5)
+	}
+}
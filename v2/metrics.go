@@ -0,0 +1,63 @@
+package querybuilder
+
+import (
+	"expvar"
+	"sync"
+	"time"
+)
+
+// Metrics observes the builds of the query builders
+type Metrics interface {
+	ObserveBuild(cmd Command, duration time.Duration, args int, err error) // Called after every build with the number of arguments
+}
+
+var (
+	metricsMu      sync.RWMutex
+	defaultMetrics Metrics
+)
+
+// SetMetrics sets the metrics of the query builders without their own metrics. A nil value turns the metrics off.
+func SetMetrics(m Metrics) {
+	metricsMu.Lock()
+	defaultMetrics = m
+	metricsMu.Unlock()
+}
+
+// WithMetrics sets the metrics of a query builder
+func WithMetrics(m Metrics) Option {
+	return func(q *QueryBuilder) error {
+		q.metrics = m
+		return nil
+	}
+}
+
+func (qb *QueryBuilder) observer() Metrics {
+	if qb.metrics != nil {
+		return qb.metrics
+	}
+	metricsMu.RLock()
+	defer metricsMu.RUnlock()
+	return defaultMetrics
+}
+
+// ExpvarMetrics publishes build metrics with expvar. The counters are keyed by command,
+// such as builds.SELECT, errors.SELECT, duration_ns.SELECT and args.SELECT.
+type ExpvarMetrics struct {
+	m *expvar.Map
+}
+
+// NewExpvarMetrics publishes the build metrics under the name. Like expvar.Publish, it panics when the name is already in use.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	return &ExpvarMetrics{m: expvar.NewMap(name)}
+}
+
+// ObserveBuild implements Metrics
+func (e *ExpvarMetrics) ObserveBuild(cmd Command, duration time.Duration, args int, err error) {
+	c := cmd.String()
+	e.m.Add("builds."+c, 1)
+	if err != nil {
+		e.m.Add("errors."+c, 1)
+	}
+	e.m.Add("duration_ns."+c, duration.Nanoseconds())
+	e.m.Add("args."+c, int64(args))
+}
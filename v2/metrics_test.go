@@ -0,0 +1,45 @@
+package querybuilder
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type recordMetrics struct {
+	cmds []Command
+	args []int
+	errs []error
+}
+
+func (r *recordMetrics) ObserveBuild(cmd Command, duration time.Duration, args int, err error) {
+	r.cmds = append(r.cmds, cmd)
+	r.args = append(r.args, args)
+	r.errs = append(r.errs, err)
+}
+
+func TestMetrics(t *testing.T) {
+	m := &recordMetrics{}
+	q := New(WithTableName("Users"), WithCommand(UPDATE), WithMetrics(m))
+	q.AddValue("UserName", "eaglebush")
+	q.AddFilter("UserKey", 5)
+	if _, _, err := q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if _, _, err := New(WithMetrics(m)).Build(); err == nil {
+		t.Fatal("expected an error")
+	}
+	if len(m.cmds) != 2 || m.cmds[0] != UPDATE || m.args[0] != 2 || m.errs[0] != nil || !errors.Is(m.errs[1], ErrNoTableSpecified) {
+		t.Errorf("unexpected observations: %v %v %v", m.cmds, m.args, m.errs)
+	}
+
+	e := NewExpvarMetrics("querybuilder_test")
+	q = New(WithTableName("Users"), WithMetrics(e))
+	q.AddColumn("UserName")
+	if _, _, err := q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if v := e.m.Get("builds.SELECT"); v == nil || v.String() != "1" {
+		t.Errorf("unexpected builds.SELECT: %v", v)
+	}
+}
@@ -0,0 +1,118 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// BuildNamedStruct builds a query with named :field placeholders for the fields of a struct, compatible
+// with sqlx.NamedExec. The struct is returned as the argument to pass along with the query.
+//
+// The fields are added as values of INSERT and UPDATE commands, and the fields tagged as keys are added as
+// filters of UPDATE, DELETE and SELECT commands. The placeholder names follow the sqlx mapping: the `db` tag,
+// or the lower case field name. The builder is left unchanged. Filters of the builder must be expressions,
+// since their values cannot be named.
+func (qb *QueryBuilder) BuildNamedStruct(v interface{}) (query string, arg interface{}, err error) {
+	rv, ok := structValue(v)
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %T is not a struct", ErrNotSupported, v)
+	}
+	c := *qb
+	c.Columns = nil
+	c.Values = nil
+	c.Filter = nil
+	c.DenyRawValues = false
+	c.VerifyPlaceholders = false
+	c.FilterFunc = nil
+	c.moreFilterFuncs = nil
+	c.captureArgs = nil
+	for _, f := range qb.Filter {
+		if f.in || !f.containsvalue && !isNil(f.value) || f.tree != nil && f.tree.bound() {
+			return "", nil, fmt.Errorf("%w: filter value of %s in a named query", ErrNotSupported, f.expression)
+		}
+		c.Filter = append(c.Filter, f)
+	}
+	if qb.CommandType == SELECT {
+		for _, col := range qb.Columns {
+			c.AddColumn(col.Name)
+		}
+	}
+	for _, f := range structFields(rv.Type()) {
+		switch {
+		case f.key && qb.CommandType != INSERT:
+			c.AddFilterExp(f.column + " = :" + f.bind)
+		case qb.CommandType == INSERT || qb.CommandType == UPDATE:
+			c.AddValue(f.column, ":"+f.bind, IsSqlString(false))
+		}
+	}
+	query, _, err = c.build(context.Background())
+	if err != nil {
+		return "", nil, err
+	}
+	return query, v, nil
+}
+
+// BuildNamed builds a query with named placeholders, for drivers and proxies that bind parameters by name.
+// The parameters are named after their columns, such as @UserKey, or p1, p2 for the values without a column,
+// such as those of FilterFunc and pagination. Repeated names are suffixed, such as UserKey_2, so the names
+// are stable for the same builder. The placeholders are prefixed with @ on SQL Server, PostgreSQL (pgx.NamedArgs)
+// and BigQuery, and with : on the other dialects. The ParameterOffset is not advanced.
+func (qb *QueryBuilder) BuildNamed() (query string, params map[string]interface{}, err error) {
+	w := *qb
+	w.memo = nil
+	w.MemoizeBuild = false
+	w.CacheQueries = false
+	var columns []string
+	w.captureArgs = func(column string, value interface{}) {
+		columns = append(columns, column)
+		qb.capture(column, value)
+	}
+	query, args, _, err := w.buildAt(context.Background(), 0)
+	if err != nil {
+		return "", nil, err
+	}
+	prefix := ":"
+	switch qb.Dialect {
+	case SQLSERVER, POSTGRES, BIGQUERY:
+		prefix = "@"
+	}
+	names := make([]interface{}, len(args))
+	params = make(map[string]interface{}, len(args))
+	for i, a := range args {
+		name := ""
+		if i < len(columns) {
+			name = paramName(columns[i])
+		}
+		if name == "" {
+			name = "p" + strconv.Itoa(i+1)
+		}
+		for n, base := 2, name; ; n++ {
+			if _, ok := params[name]; !ok {
+				break
+			}
+			name = base + "_" + strconv.Itoa(n)
+		}
+		params[name] = a
+		names[i] = name
+	}
+	return w.inlineArgs(query, names, 0, func(name interface{}) string {
+		return prefix + name.(string)
+	}), params, nil
+}
+
+// paramName returns the name of a parameter for the column of a value, such as UserKey for u.[UserKey],
+// or an empty name when the column is an expression
+func paramName(column string) string {
+	if i := strings.LastIndexByte(column, '.'); i >= 0 {
+		column = column[i+1:]
+	}
+	column = strings.Trim(column, "[]\"`")
+	for i, c := range column {
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9') {
+			return ""
+		}
+	}
+	return column
+}
@@ -0,0 +1,77 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildNamedStruct(t *testing.T) {
+	type user struct {
+		UserKey  int    `db:"user_key" qb:"UserKey,key"`
+		UserName string `db:"user_name"`
+		Email    string
+	}
+	u := user{UserKey: 5, UserName: "eaglebush"}
+
+	q := New(WithTableName("{Users}"), WithCommand(INSERT))
+	s, arg, err := q.BuildNamedStruct(&u)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Users (UserKey, user_name, Email) VALUES (:user_key,:user_name,:email);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if arg != &u {
+		t.Errorf("unexpected argument: %v", arg)
+	}
+
+	q = New(WithTableName("{Users}"), WithCommand(UPDATE), WithDialect(POSTGRES))
+	q.AddFilterExp("Active = 1")
+	s, _, err = q.BuildNamedStruct(u)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "UPDATE Users SET user_name = :user_name, Email = :email WHERE Active = 1 AND UserKey = :user_key;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if len(q.Values) != 0 || len(q.Filter) != 1 {
+		t.Errorf("builder was changed: %v %v", q.Values, q.Filter)
+	}
+}
+
+func TestBuildNamed(t *testing.T) {
+	q := New(WithTableName("Users u"), WithDialect(POSTGRES))
+	q.AddColumn("u.UserName")
+	q.AddFilter("u.UserKey", 5)
+	q.AddFilterIn("Age", 18, 65)
+	q.AddFilter("LOWER(Email)", "a@b.c")
+	q.AddFilterExp("Status <> 'x$1'")
+	s, params, err := q.BuildNamed()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT u.UserName FROM Users u WHERE u.UserKey = @UserKey AND Age IN (@Age, @Age_2) AND LOWER(Email) = @p4 AND Status <> 'x$1';"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	want := map[string]interface{}{"UserKey": 5, "Age": 18, "Age_2": 65, "p4": "a@b.c"}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("got %v, want %v", params, want)
+	}
+	if q.ParameterOffset != 0 {
+		t.Errorf("ParameterOffset was advanced to %d", q.ParameterOffset)
+	}
+
+	q = New(WithTableName("Users"), WithCommand(INSERT))
+	q.AddValue("UserName", "eaglebush")
+	q.AddValue("Email", "a@b.c")
+	s, params, err = q.BuildNamed()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Users (UserName, Email) VALUES (:UserName,:Email);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if len(params) != 2 || params["Email"] != "a@b.c" {
+		t.Errorf("unexpected parameters: %v", params)
+	}
+}
@@ -0,0 +1,50 @@
+package querybuilder
+
+// NullSafeEq matches the rows where the column equals the value, treating NULL as equal to NULL.
+// A nil value matches the NULL columns.
+func NullSafeEq(column string, value interface{}) Cond {
+	return Cond{Column: column, Operator: OpNullSafeEq, Value: value}
+}
+
+// AddFilterNullSafeEq adds a filter that matches the rows where the column equals the value, treating NULL as equal
+// to NULL, so that nullable columns are matched without checking the value first. It is rendered per dialect:
+//
+//	POSTGRES, DUCKDB, SNOWFLAKE, BIGQUERY  column IS NOT DISTINCT FROM $1
+//	MYSQL                                 column <=> ?
+//	SQLITE                                column IS ?
+//	others                                (column = @p1 OR (column IS NULL AND @p2 IS NULL))
+//
+// The expansion binds the value twice. A nil value is rendered as column IS NULL on every dialect.
+func (qb *QueryBuilder) AddFilterNullSafeEq(column string, value interface{}) *QueryBuilder {
+	return qb.Where(NullSafeEq(column, value))
+}
+
+// nullSafeExpanded reports whether the dialect has no null-safe equality operator,
+// so that the comparison is expanded and its value is bound twice
+func (qb *QueryBuilder) nullSafeExpanded() bool {
+	switch qb.Dialect {
+	case POSTGRES, DUCKDB, SNOWFLAKE, BIGQUERY, MYSQL, SQLITE:
+		return false
+	}
+	return true
+}
+
+// nullSafeClause renders a null-safe equality with a value
+func (qb *QueryBuilder) nullSafeClause(f queryFilter, paramcnt, phcnt *int) string {
+	column, ph := f.expression, qb.enumCast(f.expression, qb.placeholder(paramcnt))
+	*phcnt++
+	if f.ci {
+		column, ph = "LOWER("+column+")", "LOWER("+ph+")"
+	}
+	switch qb.Dialect {
+	case POSTGRES, DUCKDB, SNOWFLAKE, BIGQUERY:
+		return column + " IS NOT DISTINCT FROM " + ph
+	case MYSQL:
+		return column + " <=> " + ph
+	case SQLITE:
+		return column + " IS " + ph
+	}
+	ph2 := qb.placeholder(paramcnt)
+	*phcnt++
+	return "(" + column + " = " + ph + " OR (" + column + " IS NULL AND " + ph2 + " IS NULL))"
+}
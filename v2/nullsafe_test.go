@@ -0,0 +1,50 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddFilterNullSafeEq(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		want    string
+		args    []interface{}
+	}{
+		{POSTGRES, "SELECT UserName FROM Users WHERE Region IS NOT DISTINCT FROM $1 AND Active = $2;", []interface{}{"EU", true}},
+		{MYSQL, "SELECT UserName FROM Users WHERE Region <=> ? AND Active = ?;", []interface{}{"EU", true}},
+		{SQLITE, "SELECT UserName FROM Users WHERE Region IS ? AND Active = ?;", []interface{}{"EU", true}},
+		{SQLSERVER, "SELECT UserName FROM Users WHERE (Region = @p1 OR (Region IS NULL AND @p2 IS NULL)) AND Active = @p3;", []interface{}{"EU", "EU", true}},
+	}
+	for _, tt := range tests {
+		q := New(WithTableName("Users"), WithDialect(tt.dialect))
+		q.AddColumn("UserName")
+		q.AddFilterNullSafeEq("Region", "EU")
+		q.AddFilter("Active", true)
+		s, v, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if s != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.dialect, s, tt.want)
+		}
+		if !reflect.DeepEqual(v, tt.args) {
+			t.Errorf("%s: got %v, want %v", tt.dialect, v, tt.args)
+		}
+	}
+
+	q := New(WithTableName("Users"), WithDialect(ORACLE))
+	q.AddColumn("UserName")
+	q.AddFilterNullSafeEq("Region", nil)
+	q.WhereTree(Or(NullSafeEq("Country", "PH"), Eq("Vip", true)))
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users WHERE Region IS NULL AND ((Country = :1 OR (Country IS NULL AND :2 IS NULL)) OR Vip = :3)"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"PH", "PH", true}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
@@ -0,0 +1,423 @@
+// Package odata translates a safe subset of OData query options into query builder filters with bound parameters.
+//
+// The $filter option supports the eq, ne, gt, ge, lt and le comparisons, the in operator, the contains,
+// startswith and endswith functions, and the and, or and not operators with parentheses. The literals are
+// strings in single quotes, numbers, true, false and null. The $orderby option lists fields with asc or desc,
+// and $top and $skip paginate the query. Only the fields of the Spec are accepted.
+package odata
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	qb "github.com/eaglebush/querybuilder/v2"
+)
+
+// errors
+var (
+	ErrSyntax       = errors.New("invalid OData expression")
+	ErrNotAllowed   = errors.New("OData field is not allowed")
+	ErrInvalidValue = errors.New("invalid OData query option")
+)
+
+// maxDepth is the deepest nesting of parentheses and not operators accepted in a filter
+const maxDepth = 32
+
+// Spec is the allowlist of an entity set
+type Spec struct {
+	Fields map[string]string // Columns by OData field name. The fields can be filtered and ordered
+	MaxTop int               // Largest $top accepted. Zero accepts any value
+}
+
+// Apply adds the $filter, $orderby, $top and $skip options of the query parameters to the builder
+func Apply(q *qb.QueryBuilder, values url.Values, spec Spec) error {
+	if f := values.Get("$filter"); f != "" {
+		tree, err := spec.ParseFilter(f)
+		if err != nil {
+			return err
+		}
+		q.WhereTree(tree)
+	}
+	if err := spec.orderBy(q, values.Get("$orderby")); err != nil {
+		return err
+	}
+	top, err := option(values, "$top")
+	if err != nil {
+		return err
+	}
+	skip, err := option(values, "$skip")
+	if err != nil {
+		return err
+	}
+	if spec.MaxTop > 0 && (top > spec.MaxTop || top == 0) {
+		if top > spec.MaxTop {
+			return fmt.Errorf("%w: $top=%d exceeds %d", ErrInvalidValue, top, spec.MaxTop)
+		}
+		top = spec.MaxTop
+	}
+	if top > 0 {
+		q.PaginateOffset(skip, top)
+	}
+	return nil
+}
+
+// option returns the value of a numeric query option, or zero when it is missing
+func option(values url.Values, name string) (int, error) {
+	v := values.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%w: %s=%s", ErrInvalidValue, name, v)
+	}
+	return n, nil
+}
+
+// orderBy adds the fields of the $orderby option to the builder
+func (s Spec) orderBy(q *qb.QueryBuilder, orderby string) error {
+	if strings.TrimSpace(orderby) == "" {
+		return nil
+	}
+	for _, item := range strings.Split(orderby, ",") {
+		parts := strings.Fields(item)
+		if len(parts) == 0 || len(parts) > 2 {
+			return fmt.Errorf("%w: $orderby %q", ErrSyntax, item)
+		}
+		col, ok := s.Fields[parts[0]]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrNotAllowed, parts[0])
+		}
+		dir := qb.ASC
+		if len(parts) == 2 {
+			switch strings.ToLower(parts[1]) {
+			case "asc":
+			case "desc":
+				dir = qb.DESC
+			default:
+				return fmt.Errorf("%w: $orderby %q", ErrSyntax, item)
+			}
+		}
+		q.AddOrder(col, dir)
+	}
+	return nil
+}
+
+// ParseFilter parses a $filter expression into a condition tree
+func (s Spec) ParseFilter(filter string) (qb.Tree, error) {
+	toks, err := tokenize(filter)
+	if err != nil {
+		return qb.Tree{}, err
+	}
+	p := &parser{spec: s, toks: toks}
+	t, err := p.or(0)
+	if err != nil {
+		return qb.Tree{}, err
+	}
+	if p.pos < len(p.toks) {
+		return qb.Tree{}, fmt.Errorf("%w: unexpected %q", ErrSyntax, p.toks[p.pos].text)
+	}
+	return t, nil
+}
+
+// token kinds
+const (
+	tkIdent = iota
+	tkString
+	tkNumber
+	tkPunct
+)
+
+type token struct {
+	kind int
+	text string
+}
+
+// tokenize splits a filter into identifiers, string literals, numbers and punctuation
+func tokenize(s string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(' || c == ')' || c == ',':
+			toks = append(toks, token{tkPunct, string(c)})
+			i++
+		case c == '\'':
+			var sb strings.Builder
+			j := i + 1
+			for {
+				if j >= len(s) {
+					return nil, fmt.Errorf("%w: unterminated string", ErrSyntax)
+				}
+				if s[j] == '\'' {
+					// a doubled quote is an escaped quote
+					if j+1 < len(s) && s[j+1] == '\'' {
+						sb.WriteByte('\'')
+						j += 2
+						continue
+					}
+					break
+				}
+				sb.WriteByte(s[j])
+				j++
+			}
+			toks = append(toks, token{tkString, sb.String()})
+			i = j + 1
+		case c == '-' || c >= '0' && c <= '9':
+			j := i + 1
+			for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == '.') {
+				j++
+			}
+			toks = append(toks, token{tkNumber, s[i:j]})
+			i = j
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			j := i + 1
+			for j < len(s) && (s[j] == '_' || s[j] == '/' || s[j] >= 'a' && s[j] <= 'z' || s[j] >= 'A' && s[j] <= 'Z' || s[j] >= '0' && s[j] <= '9') {
+				j++
+			}
+			toks = append(toks, token{tkIdent, s[i:j]})
+			i = j
+		default:
+			return nil, fmt.Errorf("%w: unexpected %q", ErrSyntax, c)
+		}
+	}
+	return toks, nil
+}
+
+var comparisons = map[string]qb.Operator{
+	"eq": qb.OpEq,
+	"ne": qb.OpNe,
+	"gt": qb.OpGt,
+	"ge": qb.OpGte,
+	"lt": qb.OpLt,
+	"le": qb.OpLte,
+}
+
+type parser struct {
+	spec Spec
+	toks []token
+	pos  int
+}
+
+func (p *parser) peek() token {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return token{kind: -1}
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+// keyword consumes the next token when it is the keyword
+func (p *parser) keyword(kw string) bool {
+	if t := p.peek(); t.kind == tkIdent && strings.EqualFold(t.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(punct string) error {
+	if t := p.next(); t.kind != tkPunct || t.text != punct {
+		return fmt.Errorf("%w: expected %q", ErrSyntax, punct)
+	}
+	return nil
+}
+
+func (p *parser) or(depth int) (qb.Tree, error) {
+	t, err := p.and(depth)
+	if err != nil {
+		return t, err
+	}
+	nodes := []qb.Node{t}
+	for p.keyword("or") {
+		if t, err = p.and(depth); err != nil {
+			return t, err
+		}
+		nodes = append(nodes, t)
+	}
+	if len(nodes) == 1 {
+		return t, nil
+	}
+	return qb.Or(nodes...), nil
+}
+
+func (p *parser) and(depth int) (qb.Tree, error) {
+	t, err := p.unary(depth)
+	if err != nil {
+		return t, err
+	}
+	nodes := []qb.Node{t}
+	for p.keyword("and") {
+		if t, err = p.unary(depth); err != nil {
+			return t, err
+		}
+		nodes = append(nodes, t)
+	}
+	if len(nodes) == 1 {
+		return t, nil
+	}
+	return qb.And(nodes...), nil
+}
+
+func (p *parser) unary(depth int) (qb.Tree, error) {
+	if depth > maxDepth {
+		return qb.Tree{}, fmt.Errorf("%w: nested too deeply", ErrSyntax)
+	}
+	if p.keyword("not") {
+		t, err := p.unary(depth + 1)
+		if err != nil {
+			return t, err
+		}
+		return qb.Not(t), nil
+	}
+	if t := p.peek(); t.kind == tkPunct && t.text == "(" {
+		p.pos++
+		e, err := p.or(depth + 1)
+		if err != nil {
+			return e, err
+		}
+		return e, p.expect(")")
+	}
+	return p.comparison()
+}
+
+// comparison parses a comparison, an in operator or a string function
+func (p *parser) comparison() (qb.Tree, error) {
+	t := p.next()
+	if t.kind != tkIdent {
+		return qb.Tree{}, fmt.Errorf("%w: expected a field", ErrSyntax)
+	}
+	switch fn := strings.ToLower(t.text); fn {
+	case "contains", "startswith", "endswith":
+		if n := p.peek(); n.kind == tkPunct && n.text == "(" {
+			return p.function(fn)
+		}
+	}
+	col, err := p.field(t.text)
+	if err != nil {
+		return qb.Tree{}, err
+	}
+	opTok := p.next()
+	if opTok.kind != tkIdent {
+		return qb.Tree{}, fmt.Errorf("%w: expected an operator after %s", ErrSyntax, t.text)
+	}
+	if strings.EqualFold(opTok.text, "in") {
+		if err := p.expect("("); err != nil {
+			return qb.Tree{}, err
+		}
+		var values []interface{}
+		for {
+			v, err := p.literal()
+			if err != nil {
+				return qb.Tree{}, err
+			}
+			values = append(values, v)
+			if n := p.peek(); n.kind == tkPunct && n.text == "," {
+				p.pos++
+				continue
+			}
+			break
+		}
+		return tree(qb.In(col, values...)), p.expect(")")
+	}
+	op, ok := comparisons[strings.ToLower(opTok.text)]
+	if !ok {
+		return qb.Tree{}, fmt.Errorf("%w: unknown operator %q", ErrSyntax, opTok.text)
+	}
+	v, err := p.literal()
+	if err != nil {
+		return qb.Tree{}, err
+	}
+	if v == nil && op != qb.OpEq && op != qb.OpNe {
+		return qb.Tree{}, fmt.Errorf("%w: null with %s", ErrSyntax, opTok.text)
+	}
+	return tree(qb.Cond{Column: col, Operator: op, Value: v}), nil
+}
+
+// function parses contains, startswith and endswith into a LIKE condition.
+// The % and _ characters of the string are escaped, so that they match as is.
+func (p *parser) function(fn string) (qb.Tree, error) {
+	if err := p.expect("("); err != nil {
+		return qb.Tree{}, err
+	}
+	f := p.next()
+	if f.kind != tkIdent {
+		return qb.Tree{}, fmt.Errorf("%w: expected a field in %s", ErrSyntax, fn)
+	}
+	col, err := p.field(f.text)
+	if err != nil {
+		return qb.Tree{}, err
+	}
+	if err := p.expect(","); err != nil {
+		return qb.Tree{}, err
+	}
+	s := p.next()
+	if s.kind != tkString {
+		return qb.Tree{}, fmt.Errorf("%w: expected a string in %s", ErrSyntax, fn)
+	}
+	pattern := qb.EscapeLike(s.text)
+	switch fn {
+	case "contains":
+		pattern = "%" + pattern + "%"
+	case "startswith":
+		pattern += "%"
+	case "endswith":
+		pattern = "%" + pattern
+	}
+	return tree(qb.Like(col, pattern).Escape()), p.expect(")")
+}
+
+// field returns the column of an allowed field
+func (p *parser) field(name string) (string, error) {
+	col, ok := p.spec.Fields[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrNotAllowed, name)
+	}
+	return col, nil
+}
+
+// literal parses a string, number, boolean or null literal
+func (p *parser) literal() (interface{}, error) {
+	t := p.next()
+	switch t.kind {
+	case tkString:
+		return t.text, nil
+	case tkNumber:
+		if strings.Contains(t.text, ".") {
+			f, err := strconv.ParseFloat(t.text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: number %q", ErrSyntax, t.text)
+			}
+			return f, nil
+		}
+		n, err := strconv.ParseInt(t.text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: number %q", ErrSyntax, t.text)
+		}
+		return n, nil
+	case tkIdent:
+		switch strings.ToLower(t.text) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: expected a literal", ErrSyntax)
+}
+
+func tree(c qb.Cond) qb.Tree {
+	return qb.Tree{Cond: &c}
+}
@@ -0,0 +1,81 @@
+package odata
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	qb "github.com/eaglebush/querybuilder/v2"
+)
+
+var products = Spec{
+	Fields: map[string]string{"Name": "ProductName", "Price": "UnitPrice", "Category": "CategoryCode", "Discontinued": "Discontinued"},
+	MaxTop: 100,
+}
+
+func TestApply(t *testing.T) {
+	v := url.Values{}
+	v.Set("$filter", "Price ge 10.5 and (Category in ('A', 'B') or startswith(Name, 'O''Neil')) and not Discontinued eq true")
+	v.Set("$orderby", "Price desc, Name")
+	v.Set("$top", "20")
+	v.Set("$skip", "30")
+
+	q := qb.New(qb.WithTableName("Products"), qb.WithDialect(qb.POSTGRES))
+	q.AddColumn("ProductName")
+	if err := Apply(q, v, products); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	s, args, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT ProductName FROM Products WHERE (UnitPrice >= $1 AND (CategoryCode IN ($2, $3) OR ProductName LIKE $4 ESCAPE '!') AND NOT (Discontinued = $5))" +
+		" ORDER BY UnitPrice DESC, ProductName ASC LIMIT 20 OFFSET 30;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{10.5, "A", "B", "O'Neil%", true}) {
+		t.Errorf("unexpected args: %v", args)
+	}
+}
+
+func TestFunctionWildcards(t *testing.T) {
+	q := qb.New(qb.WithTableName("Products"), qb.WithDialect(qb.POSTGRES))
+	q.AddColumn("ProductName")
+	v := url.Values{}
+	v.Set("$filter", "contains(Name, '100%_off!')")
+	if err := Apply(q, v, products); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	s, args, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT ProductName FROM Products WHERE ProductName LIKE $1 ESCAPE '!' LIMIT 100 OFFSET 0;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"%100!%!_off!!%"}) {
+		t.Errorf("unexpected args: %v", args)
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	tests := []struct {
+		filter string
+		err    error
+	}{
+		{"Password eq 'x'", ErrNotAllowed},
+		{"Price eq", ErrSyntax},
+		{"Price gt null", ErrSyntax},
+		{"Name eq 'x'; DROP TABLE Products", ErrSyntax},
+		{"Name eq 'x' Price", ErrSyntax},
+		{"(Name eq 'x'", ErrSyntax},
+	}
+	for _, tt := range tests {
+		if _, err := products.ParseFilter(tt.filter); !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, want %v", tt.filter, err, tt.err)
+		}
+	}
+}
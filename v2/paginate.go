@@ -0,0 +1,96 @@
+package querybuilder
+
+import "strconv"
+
+// pagination modes
+const (
+	pageNone      = iota // no pagination
+	pageLimit            // LIMIT and OFFSET
+	pageFetch            // OFFSET and FETCH NEXT
+	pageRowNumber        // ROW_NUMBER() window in a derived table
+)
+
+// Paginate limits a SELECT command to a page of rows. The page number starts at 1.
+//
+// It renders LIMIT and OFFSET, or OFFSET and FETCH NEXT on SQL Server 2012 and Oracle 12c or later.
+// On older versions, or when RowNumberPagination is true, the query is wrapped in a derived table
+// filtered by ROW_NUMBER() with bound parameters. Pagination prevails over ResultLimit.
+func (qb *QueryBuilder) Paginate(page, size int) *QueryBuilder {
+	qb.touch()
+	if page < 1 {
+		page = 1
+	}
+	qb.pageOffset = (page - 1) * size
+	qb.pageSize = size
+	return qb
+}
+
+// PaginateOffset limits a SELECT command to size rows after skipping offset rows, for callers that
+// page by row offsets rather than page numbers. It renders the same clauses as Paginate.
+func (qb *QueryBuilder) PaginateOffset(offset, size int) *QueryBuilder {
+	qb.touch()
+	if offset < 0 {
+		offset = 0
+	}
+	qb.pageOffset = offset
+	qb.pageSize = size
+	return qb
+}
+
+// pagingMode returns the pagination mode of the dialect
+func (qb *QueryBuilder) pagingMode() int {
+	if qb.pageSize <= 0 || qb.CommandType != SELECT {
+		return pageNone
+	}
+	if qb.RowNumberPagination {
+		return pageRowNumber
+	}
+	switch qb.Dialect {
+	case SQLSERVER:
+		// OFFSET and FETCH was introduced in SQL Server 2012 (11.x)
+		if qb.DialectVersion == 0 || qb.DialectVersion >= 11 {
+			return pageFetch
+		}
+		return pageRowNumber
+	case ORACLE:
+		if qb.DialectVersion == 0 || qb.DialectVersion >= 12 {
+			return pageFetch
+		}
+		return pageRowNumber
+	}
+	return pageLimit
+}
+
+// pageClause returns the clause appended to the query for the LIMIT and FETCH modes
+func (qb *QueryBuilder) pageClause(mode int, ordered bool) string {
+	off := strconv.Itoa(qb.pageOffset)
+	size := strconv.Itoa(qb.pageSize)
+	switch mode {
+	case pageLimit:
+		return " LIMIT " + size + " OFFSET " + off
+	case pageFetch:
+		ob := ""
+		// SQL Server requires an ORDER BY for OFFSET
+		if !ordered && qb.Dialect == SQLSERVER {
+			ob = " ORDER BY (SELECT NULL)"
+		}
+		return ob + " OFFSET " + off + " ROWS FETCH NEXT " + size + " ROWS ONLY"
+	}
+	return ""
+}
+
+// orderList returns the ORDER BY list without the keyword
+func (qb *QueryBuilder) orderList() string {
+	ob := ""
+	cma := ""
+	for _, v := range qb.Order {
+		ob += cma + v.column
+		if v.order == ASC {
+			ob += " ASC"
+		} else {
+			ob += " DESC"
+		}
+		cma = ", "
+	}
+	return ob
+}
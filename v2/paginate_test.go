@@ -0,0 +1,51 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		version int
+		want    string
+		args    []interface{}
+	}{
+		{POSTGRES, 0, "SELECT UserName FROM Users WHERE Active = $1 ORDER BY UserName ASC LIMIT 20 OFFSET 40;", []interface{}{true}},
+		{SQLSERVER, 0, "SELECT UserName FROM Users WHERE Active = @p1 ORDER BY UserName ASC OFFSET 40 ROWS FETCH NEXT 20 ROWS ONLY;", []interface{}{true}},
+		{SQLSERVER, 10, "SELECT * FROM (SELECT ROW_NUMBER() OVER (ORDER BY UserName ASC) AS rn, UserName FROM Users WHERE Active = @p1) pg WHERE rn BETWEEN @p2 AND @p3 ORDER BY rn;", []interface{}{true, 41, 60}},
+	}
+	for _, tt := range tests {
+		q := New(WithTableName("Users"), WithDialect(tt.dialect), WithDialectVersion(tt.version))
+		q.AddColumn("UserName")
+		q.AddFilter("Active", true)
+		q.AddOrder("UserName", ASC)
+		q.Paginate(3, 20)
+
+		s, v, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if s != tt.want {
+			t.Errorf("%s %d: got %q, want %q", tt.dialect, tt.version, s, tt.want)
+		}
+		if !reflect.DeepEqual(v, tt.args) {
+			t.Errorf("%s %d: got args %v, want %v", tt.dialect, tt.version, v, tt.args)
+		}
+	}
+}
+
+func TestPaginateOffset(t *testing.T) {
+	q := New(WithTableName("Users"), WithDialect(POSTGRES))
+	q.AddColumn("UserName")
+	q.AddOrder("UserName", ASC)
+	q.PaginateOffset(30, 20)
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users ORDER BY UserName ASC LIMIT 20 OFFSET 30;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
@@ -0,0 +1,641 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse parses a query in the subset of SQL the builder emits into a builder, to move queries kept
+// as strings to the builder. The options are applied before the query is parsed. The supported forms are:
+//
+//	SELECT [TOP n] columns FROM table [[AS] alias] [WHERE filters] [GROUP BY columns] [ORDER BY columns] [LIMIT n]
+//	INSERT INTO table (columns) VALUES (values)
+//	UPDATE table SET column = value, ... [WHERE filters]
+//	DELETE FROM table [WHERE filters]
+//
+// The filters are joined by AND. A comparison of a column to a value with =, <>, !=, <, <=, >, >=, LIKE or ILIKE,
+// IS [NOT] NULL and IN (values) become filters with values. Other filters, such as those with OR, are added
+// as filter expressions. Values are string and number literals, NULL, TRUE and FALSE. Other values are added
+// as raw SQL values. Queries with placeholders are parsed with ParseArgs.
+//
+// Queries outside of the subset, such as joins, are returned as ErrParse.
+func Parse(sql string, options ...Option) (*QueryBuilder, error) {
+	return ParseArgs(sql, nil, options...)
+}
+
+// ParseArgs parses a query like Parse, binding the placeholders to the arguments. The placeholders
+// are ?, which are bound in order, and $n, :n and @pn, which are bound to the nth argument.
+func ParseArgs(sql string, args []interface{}, options ...Option) (*QueryBuilder, error) {
+	qb, err := NewE(options...)
+	if err != nil {
+		return nil, err
+	}
+	toks, err := tokenize(sql)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{sql: sql, toks: toks, args: args, qb: qb}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+	return qb, nil
+}
+
+type tokenKind uint8
+
+const (
+	tokWord   tokenKind = iota // keyword or identifier
+	tokString                  // string literal
+	tokNumber                  // number literal
+	tokParam                   // placeholder
+	tokPunct                   // punctuation or operator
+)
+
+type token struct {
+	kind     tokenKind
+	text     string
+	pos, end int // span of the token in the query
+}
+
+// is reports whether the token is the keyword or punctuation
+func (t token) is(s string) bool {
+	return (t.kind == tokWord || t.kind == tokPunct) && strings.EqualFold(t.text, s)
+}
+
+// tokenize splits a query into tokens
+func tokenize(sql string) ([]token, error) {
+	var toks []token
+	i := 0
+	for i < len(sql) {
+		c := sql[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+			continue
+		case c == '\'':
+			i++
+			for ; i < len(sql); i++ {
+				if sql[i] == '\\' {
+					i++
+					continue
+				}
+				if sql[i] == '\'' {
+					if i+1 < len(sql) && sql[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			if i >= len(sql) {
+				return nil, fmt.Errorf("%w: unterminated string at %d", ErrParse, start)
+			}
+			i++
+			toks = append(toks, token{tokString, sql[start:i], start, i})
+		case c >= '0' && c <= '9':
+			for i < len(sql) && (sql[i] >= '0' && sql[i] <= '9' || sql[i] == '.') {
+				i++
+			}
+			toks = append(toks, token{tokNumber, sql[start:i], start, i})
+		case c == '?':
+			i++
+			toks = append(toks, token{tokParam, "?", start, i})
+		case (c == '$' || c == ':' || c == '@') && i+1 < len(sql):
+			i++
+			if c == '@' && (sql[i] == 'p' || sql[i] == 'P') {
+				i++
+			}
+			for i < len(sql) && sql[i] >= '0' && sql[i] <= '9' {
+				i++
+			}
+			// without a number, such as in the :: cast, the character is punctuation
+			if sql[i-1] < '0' || sql[i-1] > '9' {
+				i = start + 1
+				toks = append(toks, token{tokPunct, sql[start:i], start, i})
+				break
+			}
+			toks = append(toks, token{tokParam, sql[start:i], start, i})
+		case isWordStart(c):
+			for i < len(sql) {
+				ch := sql[i]
+				if ch == '"' || ch == '[' || ch == '`' {
+					if i = quotedEnd(sql, i); i < 0 {
+						return nil, fmt.Errorf("%w: unterminated identifier at %d", ErrParse, start)
+					}
+					continue
+				}
+				if !isWordStart(ch) && !(ch >= '0' && ch <= '9') && ch != '.' && ch != '}' && !(ch == '*' && sql[i-1] == '.') {
+					break
+				}
+				i++
+			}
+			toks = append(toks, token{tokWord, sql[start:i], start, i})
+		case c == '<' || c == '>' || c == '!':
+			i++
+			if i < len(sql) && (sql[i] == '=' || c == '<' && sql[i] == '>') {
+				i++
+			}
+			toks = append(toks, token{tokPunct, sql[start:i], start, i})
+		default:
+			i++
+			toks = append(toks, token{tokPunct, sql[start:i], start, i})
+		}
+	}
+	return toks, nil
+}
+
+func isWordStart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '"' || c == '[' || c == '`' || c == '{' || c >= 0x80
+}
+
+// quotedEnd returns the position after the quoted identifier at i, or -1
+func quotedEnd(sql string, i int) int {
+	closing := sql[i]
+	if closing == '[' {
+		closing = ']'
+	}
+	j := strings.IndexByte(sql[i+1:], closing)
+	if j < 0 {
+		return -1
+	}
+	return i + j + 2
+}
+
+type parser struct {
+	sql  string
+	toks []token
+	pos  int
+	args []interface{}
+	next int // next argument of the ? placeholders
+	qb   *QueryBuilder
+}
+
+func (p *parser) peek() token {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	return token{kind: tokPunct, pos: len(p.sql), end: len(p.sql)}
+}
+
+func (p *parser) accept(keywords ...string) bool {
+	save := p.pos
+	for _, k := range keywords {
+		if !p.peek().is(k) {
+			p.pos = save
+			return false
+		}
+		p.pos++
+	}
+	return true
+}
+
+func (p *parser) expect(keywords ...string) error {
+	if !p.accept(keywords...) {
+		return p.errorf("expected %s", strings.Join(keywords, " "))
+	}
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at %d", ErrParse, fmt.Sprintf(format, args...), p.peek().pos)
+}
+
+func (p *parser) done() bool {
+	p.accept(";")
+	return p.pos >= len(p.toks)
+}
+
+// word returns the identifier at the position
+func (p *parser) word() (string, error) {
+	t := p.peek()
+	if t.kind != tokWord || isKeyword(t.text) {
+		return "", p.errorf("expected an identifier")
+	}
+	p.pos++
+	return t.text, nil
+}
+
+// keywords that end a list or a table name
+var parseKeywords = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "GROUP": true, "ORDER": true, "LIMIT": true, "OFFSET": true,
+	"SET": true, "VALUES": true, "JOIN": true, "INNER": true, "LEFT": true, "RIGHT": true, "FULL": true, "CROSS": true,
+	"UNION": true, "HAVING": true, "AND": true, "OR": true, "NOT": true, "AS": true, "ON": true, "RETURNING": true,
+}
+
+func isKeyword(s string) bool {
+	return parseKeywords[strings.ToUpper(s)]
+}
+
+func (p *parser) parse() error {
+	switch {
+	case p.accept("SELECT"):
+		return p.parseSelect()
+	case p.accept("INSERT", "INTO"):
+		return p.parseInsert()
+	case p.accept("UPDATE"):
+		return p.parseUpdate()
+	case p.accept("DELETE", "FROM"):
+		return p.parseDelete()
+	}
+	return p.errorf("expected SELECT, INSERT, UPDATE or DELETE")
+}
+
+func (p *parser) parseSelect() error {
+	p.qb.CommandType = SELECT
+	if p.accept("TOP") {
+		t := p.peek()
+		if t.kind != tokNumber {
+			return p.errorf("expected the number of rows")
+		}
+		p.pos++
+		p.qb.ResultLimit = t.text
+		p.qb.ResultLimitPosition = FRONT
+	}
+	for _, c := range p.list("FROM") {
+		p.qb.AddColumn(c)
+	}
+	if err := p.expect("FROM"); err != nil {
+		return err
+	}
+	if err := p.table(true); err != nil {
+		return err
+	}
+	if err := p.parseWhere(); err != nil {
+		return err
+	}
+	if p.accept("GROUP", "BY") {
+		for _, g := range p.list("HAVING", "ORDER", "LIMIT", ";") {
+			p.qb.AddGroup(g)
+		}
+	}
+	if p.accept("ORDER", "BY") {
+		for _, o := range p.list("LIMIT", ";") {
+			sort := ASC
+			if f := strings.Fields(o); len(f) > 1 {
+				switch strings.ToUpper(f[len(f)-1]) {
+				case "DESC":
+					sort = DESC
+					o = strings.TrimSpace(o[:len(o)-4])
+				case "ASC":
+					o = strings.TrimSpace(o[:len(o)-3])
+				}
+			}
+			p.qb.AddOrder(o, sort)
+		}
+	}
+	if p.accept("LIMIT") {
+		t := p.peek()
+		if t.kind != tokNumber || p.qb.ResultLimit != "" {
+			return p.errorf("expected the number of rows")
+		}
+		p.pos++
+		p.qb.ResultLimit = t.text
+		p.qb.ResultLimitPosition = REAR
+	}
+	if !p.done() {
+		return p.errorf("unexpected %q", p.peek().text)
+	}
+	return nil
+}
+
+func (p *parser) parseInsert() error {
+	p.qb.CommandType = INSERT
+	if err := p.table(false); err != nil {
+		return err
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	cols := p.list(")")
+	if err := p.expect(")"); err != nil {
+		return err
+	}
+	if err := p.expect("VALUES", "("); err != nil {
+		return err
+	}
+	vals := p.spans(")")
+	if err := p.expect(")"); err != nil {
+		return err
+	}
+	if len(cols) != len(vals) {
+		return p.errorf("%d columns, %d values", len(cols), len(vals))
+	}
+	for i, c := range cols {
+		if err := p.value(c, vals[i]); err != nil {
+			return err
+		}
+	}
+	if !p.done() {
+		return p.errorf("unexpected %q", p.peek().text)
+	}
+	return nil
+}
+
+func (p *parser) parseUpdate() error {
+	p.qb.CommandType = UPDATE
+	if err := p.table(false); err != nil {
+		return err
+	}
+	if err := p.expect("SET"); err != nil {
+		return err
+	}
+	for _, s := range p.spans("WHERE", ";") {
+		if len(s) < 3 || s[0].kind != tokWord || !s[1].is("=") {
+			return fmt.Errorf("%w: expected column = value at %d", ErrParse, s[0].pos)
+		}
+		if err := p.value(s[0].text, s[2:]); err != nil {
+			return err
+		}
+	}
+	if err := p.parseWhere(); err != nil {
+		return err
+	}
+	if !p.done() {
+		return p.errorf("unexpected %q", p.peek().text)
+	}
+	return nil
+}
+
+func (p *parser) parseDelete() error {
+	p.qb.CommandType = DELETE
+	if err := p.table(false); err != nil {
+		return err
+	}
+	if err := p.parseWhere(); err != nil {
+		return err
+	}
+	if !p.done() {
+		return p.errorf("unexpected %q", p.peek().text)
+	}
+	return nil
+}
+
+// table parses the table of the query, and its alias when allowed
+func (p *parser) table(alias bool) error {
+	name, err := p.word()
+	if err != nil {
+		return err
+	}
+	as := ""
+	if alias {
+		if p.accept("AS") {
+			if as, err = p.word(); err != nil {
+				return err
+			}
+		} else if t := p.peek(); t.kind == tokWord && !isKeyword(t.text) {
+			as = t.text
+			p.pos++
+		}
+	}
+	p.qb.SourceAs(name, as)
+	return nil
+}
+
+// spans splits the tokens up to a stop keyword, or an unmatched parenthesis, by the commas outside of parentheses
+func (p *parser) spans(stop ...string) [][]token {
+	var spans [][]token
+	var cur []token
+	depth := 0
+loop:
+	for ; p.pos < len(p.toks); p.pos++ {
+		t := p.toks[p.pos]
+		if depth == 0 {
+			for _, s := range stop {
+				if t.is(s) {
+					break loop
+				}
+			}
+		}
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+		case t.is(",") && depth == 0:
+			spans = append(spans, cur)
+			cur = nil
+			continue
+		}
+		cur = append(cur, t)
+	}
+	if cur != nil {
+		spans = append(spans, cur)
+	}
+	return spans
+}
+
+// list returns the text of the spans up to a stop keyword
+func (p *parser) list(stop ...string) []string {
+	spans := p.spans(stop...)
+	list := make([]string, len(spans))
+	for i, s := range spans {
+		list[i] = p.text(s)
+	}
+	return list
+}
+
+// text returns the query text of the tokens
+func (p *parser) text(toks []token) string {
+	if len(toks) == 0 {
+		return ""
+	}
+	return p.sql[toks[0].pos:toks[len(toks)-1].end]
+}
+
+// literal returns the value of a span that is a literal or a placeholder
+func (p *parser) literal(toks []token) (interface{}, bool, error) {
+	neg := len(toks) == 2 && toks[0].is("-") && toks[1].kind == tokNumber
+	if neg {
+		toks = toks[1:]
+	}
+	if len(toks) != 1 {
+		return nil, false, nil
+	}
+	t := toks[0]
+	switch t.kind {
+	case tokString:
+		s := t.text[1 : len(t.text)-1]
+		s = strings.ReplaceAll(s, "''", "'")
+		s = strings.ReplaceAll(s, `\'`, "'")
+		return s, true, nil
+	case tokNumber:
+		text := t.text
+		if neg {
+			text = "-" + text
+		}
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return n, true, nil
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: invalid number %s at %d", ErrParse, text, t.pos)
+		}
+		return f, true, nil
+	case tokParam:
+		idx := p.next
+		if t.text == "?" {
+			p.next++
+		} else {
+			n, _ := strconv.Atoi(strings.TrimLeft(t.text, "$:@pP"))
+			idx = n - 1
+		}
+		if idx < 0 || idx >= len(p.args) {
+			return nil, false, fmt.Errorf("%w: no argument for %s at %d", ErrParse, t.text, t.pos)
+		}
+		return p.args[idx], true, nil
+	case tokWord:
+		switch strings.ToUpper(t.text) {
+		case "NULL":
+			return nil, true, nil
+		case "TRUE":
+			return true, true, nil
+		case "FALSE":
+			return false, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// value adds the value of a column
+func (p *parser) value(column string, toks []token) error {
+	v, ok, err := p.literal(toks)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if err := p.noParams(toks); err != nil {
+			return err
+		}
+		p.qb.AddValue(column, p.text(toks), IsSqlString(false))
+		return nil
+	}
+	p.qb.AddValue(column, v)
+	return nil
+}
+
+// noParams returns an error when the raw SQL has placeholders, since their arguments cannot be bound
+func (p *parser) noParams(toks []token) error {
+	for _, t := range toks {
+		if t.kind == tokParam {
+			return fmt.Errorf("%w: placeholder %s in an expression at %d", ErrParse, t.text, t.pos)
+		}
+	}
+	return nil
+}
+
+// parseWhere parses the filters of the WHERE clause joined by AND
+func (p *parser) parseWhere() error {
+	if !p.accept("WHERE") {
+		return nil
+	}
+	var cur []token
+	depth, between := 0, false
+	for ; p.pos < len(p.toks); p.pos++ {
+		t := p.toks[p.pos]
+		if depth == 0 && (t.is("GROUP") || t.is("ORDER") || t.is("LIMIT") || t.is(";")) {
+			break
+		}
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+		case t.is("BETWEEN"):
+			between = true
+		case t.is("AND") && depth == 0:
+			if !between {
+				if err := p.filter(cur); err != nil {
+					return err
+				}
+				cur = nil
+				continue
+			}
+			between = false
+		}
+		cur = append(cur, t)
+	}
+	return p.filter(cur)
+}
+
+// filter adds a filter of the WHERE clause
+func (p *parser) filter(toks []token) error {
+	if len(toks) == 0 {
+		return p.errorf("expected a filter")
+	}
+	col := toks[0]
+	if col.kind == tokWord && !isKeyword(col.text) && len(toks) > 1 {
+		rest := toks[1:]
+		switch {
+		case len(rest) == 2 && rest[0].is("IS") && rest[1].is("NULL"):
+			p.qb.AddFilter(col.text, nil)
+			return nil
+		case len(rest) == 3 && rest[0].is("IS") && rest[1].is("NOT") && rest[2].is("NULL"):
+			p.qb.Where(Ne(col.text, nil))
+			return nil
+		case len(rest) >= 3 && rest[0].is("IN") && rest[1].is("(") && rest[len(rest)-1].is(")"):
+			sub := &parser{sql: p.sql, toks: rest[2 : len(rest)-1]}
+			var vals []interface{}
+			for _, s := range sub.spans() {
+				v, ok, err := p.literal(s)
+				if err != nil {
+					return err
+				}
+				if !ok {
+					vals = nil
+					break
+				}
+				vals = append(vals, v)
+			}
+			if vals != nil {
+				p.qb.AddFilterIn(col.text, vals...)
+				return nil
+			}
+		default:
+			if op, ok := parseOperator(rest[0]); ok {
+				v, ok, err := p.literal(rest[1:])
+				if err != nil {
+					return err
+				}
+				// a placeholder bound to nil compares with IS NULL, as the filters of the builder do
+				if ok && (v != nil || rest[1].kind == tokParam) {
+					if op == OpEq {
+						p.qb.AddFilter(col.text, v)
+					} else {
+						p.qb.Where(Cond{Column: col.text, Operator: op, Value: v})
+					}
+					return nil
+				}
+			}
+		}
+	}
+	if err := p.noParams(toks); err != nil {
+		return err
+	}
+	p.qb.AddFilterExp(p.text(toks))
+	return nil
+}
+
+// parseOperator returns the comparison operator of a token
+func parseOperator(t token) (Operator, bool) {
+	switch strings.ToUpper(t.text) {
+	case "=":
+		return OpEq, true
+	case "<>", "!=":
+		return OpNe, true
+	case "<":
+		return OpLt, true
+	case "<=":
+		return OpLte, true
+	case ">":
+		return OpGt, true
+	case ">=":
+		return OpGte, true
+	case "LIKE":
+		return OpLike, true
+	case "ILIKE":
+		return OpILike, true
+	}
+	return "", false
+}
@@ -0,0 +1,74 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		sql  string
+		args []interface{}
+		want string
+		vals []interface{}
+	}{
+		{
+			"select UserName, COUNT(*) AS n from {Users} u where Status = 'O''Brien' and Age >= 18 and Code IN (1, 2) " +
+				"and DeletedAt IS NULL and (Age < 5 OR Age > 60) group by UserName order by UserName desc, n limit 10;",
+			nil,
+			"SELECT UserName, COUNT(*) AS n FROM Users u WHERE Status = $1 AND Age >= $2 AND Code IN ($3, $4) " +
+				"AND DeletedAt IS NULL AND (Age < 5 OR Age > 60) GROUP BY UserName ORDER BY UserName DESC, n ASC LIMIT 10;",
+			[]interface{}{"O'Brien", int64(18), int64(1), int64(2)},
+		},
+		{
+			"INSERT INTO Users (UserName, Age, CreatedAt, Notes) VALUES (?, -3, CURRENT_TIMESTAMP, NULL)",
+			[]interface{}{"a"},
+			"INSERT INTO Users (UserName, Age, CreatedAt, Notes) VALUES ($1,$2,CURRENT_TIMESTAMP,NULL);",
+			[]interface{}{"a", int64(-3)},
+		},
+		{
+			"UPDATE Users SET UserName = $2, Score = Score + 1 WHERE UserKey = $1 AND Email IS NOT NULL AND Tags::text LIKE 'a%'",
+			[]interface{}{5, "b"},
+			"UPDATE Users SET UserName = $1, Score = Score + 1 WHERE UserKey = $2 AND Email IS NOT NULL AND Tags::text LIKE 'a%';",
+			[]interface{}{"b", 5},
+		},
+		{
+			"DELETE FROM Users WHERE Age BETWEEN 1 AND 5 AND Active = TRUE",
+			nil,
+			"DELETE FROM Users WHERE Age BETWEEN 1 AND 5 AND Active = $1;",
+			[]interface{}{true},
+		},
+	}
+	for _, tt := range tests {
+		q, err := ParseArgs(tt.sql, tt.args, WithDialect(POSTGRES))
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		s, args, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if s != tt.want {
+			t.Errorf("got %q, want %q", s, tt.want)
+		}
+		if !reflect.DeepEqual(args, tt.vals) {
+			t.Errorf("got %v, want %v", args, tt.vals)
+		}
+	}
+
+	for _, sql := range []string{
+		"SELECT a FROM Users JOIN Groups ON 1 = 1",
+		"SELECT a FROM Users, Groups",
+		"MERGE INTO Users",
+		"SELECT a FROM Users WHERE Name = 'x",
+		"UPDATE Users SET a = ? WHERE (b = ? OR c = 1)",
+	} {
+		if _, err := ParseArgs(sql, []interface{}{1, 2}); !errors.Is(err, ErrParse) {
+			t.Errorf("%s: expected ErrParse, got %v", sql, err)
+		}
+	}
+	if _, err := Parse("SELECT a FROM Users WHERE b = ?"); !errors.Is(err, ErrParse) {
+		t.Errorf("expected ErrParse, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+)
+
+// PgxBatch is the queuing method of a pgx batch. It is implemented by *pgx.Batch of pgx v5,
+// where R is *pgx.QueuedQuery, so that the package does not depend on pgx.
+type PgxBatch[R any] interface {
+	Queue(query string, arguments ...interface{}) R
+}
+
+// Queue builds the query builders and queues the statements into a pgx batch, to be sent in a single
+// round-trip with SendBatch. Every statement is built on its own, so the placeholders of each start at $1
+// regardless of the ParameterOffset of its builder, which is not advanced.
+// When a builder fails, nothing is queued. The queued queries are returned in the order of the builders.
+func Queue[R any](batch PgxBatch[R], builders ...*QueryBuilder) ([]R, error) {
+	queries := make([]string, len(builders))
+	argsets := make([][]interface{}, len(builders))
+	for i, qb := range builders {
+		q, a, _, err := qb.buildAt(context.Background(), 0)
+		if err != nil {
+			return nil, fmt.Errorf("batch statement %d: %w", i+1, err)
+		}
+		queries[i], argsets[i] = q, a
+	}
+	queued := make([]R, len(builders))
+	for i, q := range queries {
+		queued[i] = batch.Queue(q, argsets[i]...)
+	}
+	return queued, nil
+}
@@ -0,0 +1,44 @@
+package querybuilder
+
+import (
+	"errors"
+	"testing"
+)
+
+// fakeBatch mirrors *pgx.Batch
+type fakeBatch struct {
+	queries []string
+	args    [][]interface{}
+}
+
+func (b *fakeBatch) Queue(query string, arguments ...interface{}) *string {
+	b.queries = append(b.queries, query)
+	b.args = append(b.args, arguments)
+	return &b.queries[len(b.queries)-1]
+}
+
+func TestQueue(t *testing.T) {
+	ins := New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(POSTGRES))
+	ins.AddValue("OrderKey", 10)
+	del := New(WithTableName("Carts"), WithCommand(DELETE), WithDialect(POSTGRES))
+	del.AddFilter("CartKey", 3)
+
+	del.ParameterOffset = 4
+
+	b := &fakeBatch{}
+	qq, err := Queue[*string](b, ins, del)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if len(qq) != 2 || b.queries[1] != "DELETE FROM Carts WHERE CartKey = $1;" {
+		t.Errorf("unexpected queued queries: %q", b.queries)
+	}
+	if del.ParameterOffset != 4 {
+		t.Errorf("unexpected parameter offset %d", del.ParameterOffset)
+	}
+
+	b = &fakeBatch{}
+	if _, err := Queue[*string](b, ins, New(WithDialect(POSTGRES))); !errors.Is(err, ErrNoTableSpecified) || len(b.queries) != 0 {
+		t.Errorf("expected nothing queued with ErrNoTableSpecified, got %v", err)
+	}
+}
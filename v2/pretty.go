@@ -0,0 +1,69 @@
+package querybuilder
+
+import (
+	"context"
+	"strings"
+)
+
+// clauses are the keywords that start a new line in a pretty-printed query
+var clauses = []string{
+	"GROUP BY ", "HAVING ", "QUALIFY ", "ORDER BY ", "LIMIT ", "OFFSET ", "FETCH ",
+	"VALUES ", "SET ", "OUTPUT ", "RETURNING ", "ON CONFLICT ", "ON DUPLICATE KEY ",
+}
+
+// BuildPretty builds the query with every clause on its own line and the filters indented,
+// for review artifacts, migration scripts and logs. The Layout and CompactSQL settings of
+// the builder are ignored. Like Build, it advances the ParameterOffset.
+func (qb *QueryBuilder) BuildPretty() (query string, args []interface{}, err error) {
+	w := *qb
+	w.memo = nil
+	w.MemoizeBuild = false
+	w.CompactSQL = false
+	w.Layout = LayoutReadable
+	if query, args, err = w.build(context.Background()); err != nil {
+		return "", nil, err
+	}
+	qb.ParameterOffset = w.ParameterOffset
+	return pretty(query, qb.StringEnclosingChar, qb.StringEscapeChar), args, nil
+}
+
+// pretty breaks a query in the readable layout before the clauses outside of string literals and parentheses
+func pretty(query, enclosing, escape string) string {
+	var sb strings.Builder
+	inStr := false
+	depth := 0
+	for i := 0; i < len(query); i++ {
+		if n := escaped(query[i:], inStr, enclosing, escape); n > 0 {
+			sb.WriteString(query[i : i+n])
+			i += n - 1
+			continue
+		}
+		if enclosing != "" && strings.HasPrefix(query[i:], enclosing) {
+			inStr = !inStr
+		}
+		c := query[i]
+		if !inStr {
+			switch c {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			case ' ':
+				if depth == 0 && isClause(query[i+1:]) {
+					c = '\n'
+				}
+			}
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+func isClause(s string) bool {
+	for _, c := range clauses {
+		if strings.HasPrefix(s, c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildPretty(t *testing.T) {
+	q := New(WithTableName("Users"), WithDialect(POSTGRES))
+	q.ResultLimit = "10"
+	q.AddColumn("UserName").AddColumn("Age")
+	q.AddFilter("Status", "A")
+	q.AddFilterIn("GroupKey", 1, 2)
+	q.AddFilterExp("Notes <> 'ORDER BY x'")
+	q.AddOrder("UserName", ASC)
+	s, args, err := q.BuildPretty()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT UserName, Age\nFROM Users\nWHERE Status = $1\n  AND GroupKey IN ($2, $3)\n  AND Notes <> 'ORDER BY x'\nORDER BY UserName ASC\nLIMIT 10;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"A", 1, 2}) {
+		t.Errorf("unexpected args: %v", args)
+	}
+
+	// the execution form is unchanged
+	q.ParameterOffset = 0
+	if s, _, _ = q.Build(); s != "SELECT UserName, Age FROM Users WHERE Status = $1 AND GroupKey IN ($2, $3) AND Notes <> 'ORDER BY x' ORDER BY UserName ASC LIMIT 10;" {
+		t.Errorf("unexpected query: %q", s)
+	}
+
+	q = New(WithTableName("Users"), WithCommand(UPDATE), WithDialect(POSTGRES))
+	q.CompactSQL = true
+	q.AddValue("UserName", "a")
+	q.AddFilter("UserKey", 5)
+	q.Returning("UserKey")
+	if s, _, _ = q.BuildPretty(); s != "UPDATE Users\nSET UserName = $1\nWHERE UserKey = $2\nRETURNING UserKey;" {
+		t.Errorf("unexpected query: %q", s)
+	}
+}
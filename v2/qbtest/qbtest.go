@@ -0,0 +1,166 @@
+// Package qbtest checks the queries of query builders in tests:
+//
+//	qbtest.AssertSQL(t, q, "SELECT UserName FROM Users WHERE UserKey = ?", []interface{}{5})
+//	qbtest.AssertGolden(t, q, "testdata/users.golden")
+//
+// The queries are compared after Normalize, so that the layout and the placeholders of the dialect
+// do not matter. Golden files are rewritten with go test -qbtest.update.
+package qbtest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	qb "github.com/eaglebush/querybuilder/v2"
+)
+
+var update = flag.Bool("qbtest.update", false, "rewrite the golden files of qbtest")
+
+// AssertSQL builds the query and reports an error when its normalized SQL or its arguments differ from the wanted ones
+func AssertSQL(t testing.TB, q *qb.QueryBuilder, wantSQL string, wantArgs []interface{}) {
+	t.Helper()
+	query, args, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if Normalize(query) != Normalize(wantSQL) {
+		t.Errorf("got %q, want %q", query, wantSQL)
+	}
+	for _, d := range DiffArgs(args, wantArgs) {
+		t.Error(d)
+	}
+}
+
+// AssertGolden builds the query and compares its normalized SQL and its arguments with a golden file.
+// The file is written when it does not exist or when the tests run with -qbtest.update.
+func AssertGolden(t testing.TB, q *qb.QueryBuilder, path string) {
+	t.Helper()
+	query, args, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	got := golden(query, args)
+	want, err := os.ReadFile(path)
+	if *update || os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s: got\n%s\nwant\n%s", path, got, want)
+	}
+}
+
+// golden returns the content of a golden file: the normalized query, then the arguments
+func golden(query string, args []interface{}) []byte {
+	var b bytes.Buffer
+	b.WriteString(Normalize(query) + "\n")
+	for i, a := range args {
+		fmt.Fprintf(&b, "-- %d: %#v\n", i+1, a)
+	}
+	return b.Bytes()
+}
+
+// DiffArgs returns the differences between the arguments and the wanted arguments, one per argument
+func DiffArgs(args, want []interface{}) []string {
+	var diffs []string
+	for i := 0; i < len(args) || i < len(want); i++ {
+		switch {
+		case i >= len(want):
+			diffs = append(diffs, fmt.Sprintf("argument %d: got %#v, want none", i+1, args[i]))
+		case i >= len(args):
+			diffs = append(diffs, fmt.Sprintf("argument %d: got none, want %#v", i+1, want[i]))
+		case !reflect.DeepEqual(args[i], want[i]):
+			diffs = append(diffs, fmt.Sprintf("argument %d: got %#v, want %#v", i+1, args[i], want[i]))
+		}
+	}
+	return diffs
+}
+
+// Normalize returns the query with the whitespace outside of string literals collapsed to single spaces,
+// without spaces inside parentheses and around commas, with the ?, $n, @pn and :n placeholders replaced by ?,
+// and without the trailing semicolon.
+func Normalize(query string) string {
+	var sb strings.Builder
+	inStr := false
+	space := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		if c == '\'' {
+			inStr = !inStr
+		}
+		if inStr || c == '\'' {
+			if space {
+				sb.WriteByte(' ')
+				space = false
+			}
+			sb.WriteByte(c)
+			continue
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			space = sb.Len() > 0
+			continue
+		case '(', ',':
+			space = false
+			sb.WriteByte(c)
+			for i+1 < len(query) && strings.IndexByte(" \t\r\n", query[i+1]) >= 0 {
+				i++
+			}
+			continue
+		case ')':
+			space = false
+			sb.WriteByte(c)
+			continue
+		}
+		if space {
+			sb.WriteByte(' ')
+			space = false
+		}
+		if n := placeholder(query[i:]); n > 0 {
+			sb.WriteByte('?')
+			i += n - 1
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return strings.TrimSuffix(strings.TrimSpace(sb.String()), ";")
+}
+
+// placeholder returns the length of the placeholder at the start of s, or zero
+func placeholder(s string) int {
+	switch {
+	case s[0] == '?':
+		return 1
+	case strings.HasPrefix(s, "@p"):
+		return digits(s, 2)
+	case s[0] == '$' || s[0] == ':':
+		return digits(s, 1)
+	}
+	return 0
+}
+
+// digits returns the length of the prefix and the digits after it, or zero without digits
+func digits(s string, prefix int) int {
+	n := prefix
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	if n == prefix {
+		return 0
+	}
+	return n
+}
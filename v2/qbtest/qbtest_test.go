@@ -0,0 +1,79 @@
+package qbtest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qb "github.com/eaglebush/querybuilder/v2"
+)
+
+// recorder records the errors of an assertion
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Error(args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprint(args...))
+}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.TB.Fatalf(format, args...)
+}
+
+func TestNormalize(t *testing.T) {
+	got := Normalize("SELECT  a,b \rFROM Users\r\t WHERE x IN ( $1 , $2 ) AND y = 'a  $1' AND z::int = @p3;")
+	if want := "SELECT a,b FROM Users WHERE x IN(?,?) AND y = 'a  $1' AND z::int = ?"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestAssertSQL(t *testing.T) {
+	q := qb.New(qb.WithTableName("Users"), qb.WithDialect(qb.POSTGRES))
+	q.AddColumn("UserName")
+	q.AddFilterIn("UserKey", 1, 2)
+
+	r := &recorder{TB: t}
+	AssertSQL(r, q, "SELECT UserName\nFROM Users\nWHERE UserKey IN (?, ?)", []interface{}{1, 2})
+	if len(r.errors) != 0 {
+		t.Errorf("unexpected errors: %v", r.errors)
+	}
+
+	r = &recorder{TB: t}
+	AssertSQL(r, q, "SELECT UserName FROM Users WHERE UserKey IN (?)", []interface{}{1, int64(2), 3})
+	if len(r.errors) != 3 {
+		t.Errorf("unexpected errors: %q", r.errors)
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	q := qb.New(qb.WithTableName("Users"))
+	q.AddColumn("UserName")
+	q.AddFilter("Status", "A")
+	path := filepath.Join(t.TempDir(), "users.golden")
+
+	r := &recorder{TB: t}
+	AssertGolden(r, q, path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users WHERE Status = ?\n-- 1: \"A\"\n"; string(data) != want {
+		t.Errorf("got %q, want %q", data, want)
+	}
+
+	AssertGolden(r, q, path)
+	q.AddFilter("Age", 18)
+	AssertGolden(r, q, path)
+	if len(r.errors) != 1 {
+		t.Errorf("unexpected errors: %q", r.errors)
+	}
+}
@@ -0,0 +1,101 @@
+package querybuilder
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Qualify filters the rows of a SELECT command on the result of window functions, such as
+// ROW_NUMBER() OVER (PARTITION BY CustomerKey ORDER BY OrderDate DESC) = 1.
+// Each ? mark in the expression is bound to the next argument.
+//
+// Dialects that support QUALIFY render the clause as is. Elsewhere, the expression is computed
+// as a column of a derived table that the outer query filters and orders.
+func (qb *QueryBuilder) Qualify(expr string, args ...interface{}) *QueryBuilder {
+	qb.touch()
+	qb.qualifyExpr = expr
+	qb.qualifyArgs = args
+	return qb
+}
+
+// placeholder returns the next parameter placeholder
+func (qb *QueryBuilder) placeholder(paramcnt *int) string {
+	if !qb.ParameterInSequence {
+		return qb.ParameterChar
+	}
+	*paramcnt++
+	return qb.ParameterChar + strconv.Itoa(*paramcnt)
+}
+
+// bindMarks replaces the ? marks outside of string literals, quoted identifiers and comments with parameter placeholders.
+// It returns the expression and the number of marks replaced.
+func (qb *QueryBuilder) bindMarks(expr string, paramcnt *int) (string, int) {
+	var sb strings.Builder
+	cnt := 0
+	masked := qb.maskQuoted(expr)
+	for i := 0; i < len(expr); i++ {
+		if masked[i] == '?' {
+			sb.WriteString(qb.placeholder(paramcnt))
+			cnt++
+			continue
+		}
+		sb.WriteByte(expr[i])
+	}
+	return sb.String(), cnt
+}
+
+// maskQuoted returns the expression with the string literals, the identifiers quoted with double quotes or
+// the ReservedWordEscapeChar and the comments replaced by as many x characters, so that it can be searched
+// for marks and keywords at the same positions. Unterminated ones run to the end of the expression.
+func (qb *QueryBuilder) maskQuoted(expr string) string {
+	ec := ParseReserveWordsChars(qb.ReservedWordEscapeChar)
+	b := []byte(expr)
+	for i := 0; i < len(b); i++ {
+		var from int
+		var closing string
+		switch {
+		case b[i] == '\'':
+			from, closing = i+1, "'"
+		case b[i] == '"':
+			from, closing = i+1, `"`
+		case expr[i:i+1] == ec[0]:
+			from, closing = i+1, ec[1]
+		case strings.HasPrefix(expr[i:], "--"):
+			from, closing = i+2, "\n"
+		case strings.HasPrefix(expr[i:], "/*"):
+			from, closing = i+2, "*/"
+		default:
+			continue
+		}
+		end := len(b)
+		if j := strings.Index(expr[from:], closing); j >= 0 {
+			end = from + j + len(closing)
+		}
+		for k := i; k < end; k++ {
+			b[k] = 'x'
+		}
+		i = end - 1
+	}
+	return string(b)
+}
+
+// qualifyColumns returns the output names of the selected columns, which the outer query
+// of the emulated QUALIFY projects instead of exposing the computed qualified column
+func (qb *QueryBuilder) qualifyColumns() string {
+	cols := make([]string, 0, len(qb.Values))
+	for _, v := range qb.Values {
+		c := strings.TrimSpace(v.column)
+		// the keywords and the separators in quoted identifiers, such as [Order Date], are not searched
+		m := qb.maskQuoted(c)
+		if i := strings.LastIndex(strings.ToUpper(m), " AS "); i >= 0 {
+			c = strings.TrimSpace(c[i+4:])
+		} else if i := strings.LastIndexAny(m, ". "); i >= 0 && !strings.ContainsAny(m, "()") {
+			c = c[i+1:]
+		}
+		cols = append(cols, c)
+	}
+	if len(cols) == 0 {
+		return "*"
+	}
+	return strings.Join(cols, ", ")
+}
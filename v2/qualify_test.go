@@ -0,0 +1,109 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQualify(t *testing.T) {
+	q := New(WithTableName("Orders"), WithDialect(SNOWFLAKE))
+	q.AddColumn("CustomerKey").AddColumn("OrderDate")
+	q.AddFilter("Status", "A")
+	q.Qualify("ROW_NUMBER() OVER (PARTITION BY CustomerKey ORDER BY OrderDate DESC) <= ?", 3)
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT CustomerKey, OrderDate FROM Orders WHERE Status = ? QUALIFY ROW_NUMBER() OVER (PARTITION BY CustomerKey ORDER BY OrderDate DESC) <= ?;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"A", 3}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	q = New(WithTableName("Orders"), WithDialect(POSTGRES))
+	q.AddColumn("CustomerKey").AddColumn("OrderDate")
+	q.AddFilter("Status", "A")
+	q.AddOrder("OrderDate", DESC)
+	q.Qualify("ROW_NUMBER() OVER (PARTITION BY CustomerKey ORDER BY OrderDate DESC) <= ?", 3)
+
+	s, v, err = q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want = "SELECT CustomerKey, OrderDate FROM (SELECT CustomerKey, OrderDate, CASE WHEN ROW_NUMBER() OVER (PARTITION BY CustomerKey ORDER BY OrderDate DESC) <= $1 THEN 1 ELSE 0 END AS qualified FROM Orders WHERE Status = $2) qf WHERE qualified = 1 ORDER BY OrderDate DESC;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{3, "A"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
+
+func TestQualifyEmulatedLimit(t *testing.T) {
+	q := New(WithTableName("Orders"), WithDialect(SQLSERVER))
+	q.AddColumn("o.CustomerKey").AddColumn("OrderDate AS Ordered")
+	q.ResultLimit = "10"
+	q.Qualify("ROW_NUMBER() OVER (PARTITION BY CustomerKey ORDER BY OrderDate DESC) = 1")
+
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT TOP 10 CustomerKey, Ordered FROM (SELECT o.CustomerKey, OrderDate AS Ordered, CASE WHEN ROW_NUMBER() OVER (PARTITION BY CustomerKey ORDER BY OrderDate DESC) = 1 THEN 1 ELSE 0 END AS qualified FROM Orders) qf WHERE qualified = 1;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
+
+func TestQualifyTableReference(t *testing.T) {
+	q := New(WithTableName("{Orders}"), WithSchema("sales"), WithDialect(SNOWFLAKE))
+	q.AddColumn("{Orders}.cust")
+	q.Qualify("ROW_NUMBER() OVER (PARTITION BY {Orders}.cust ORDER BY {Orders}.total DESC) = 1")
+
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT sales.Orders.cust FROM sales.Orders QUALIFY ROW_NUMBER() OVER (PARTITION BY sales.Orders.cust ORDER BY sales.Orders.total DESC) = 1;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
+
+func TestQualifyQuotedIdentifiers(t *testing.T) {
+	q := New(WithTableName("Orders"), WithDialect(SQLSERVER))
+	q.AddColumn("[Customer Key]").AddColumn("o.[Order Date]")
+	q.AddFilter("Status", "A")
+	q.Qualify("ROW_NUMBER() OVER (PARTITION BY [Customer Key] ORDER BY [Is Due?] DESC) /* first? */ <= ?", 3)
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT [Customer Key], [Order Date] FROM (SELECT [Customer Key], o.[Order Date], CASE WHEN ROW_NUMBER() OVER (PARTITION BY [Customer Key] ORDER BY [Is Due?] DESC) /* first? */ <= @p1 THEN 1 ELSE 0 END AS qualified FROM Orders WHERE Status = @p2) qf WHERE qualified = 1;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{3, "A"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	q = New(WithTableName("Orders"), WithDialect(POSTGRES))
+	q.AddColumn(`"Customer Key"`).AddColumn(`o."Order Date" AS "Due AS Of"`)
+	q.Qualify(`ROW_NUMBER() OVER (PARTITION BY "Customer Key" ORDER BY "Is Due?" DESC) <= ? /* top ? rows */`, 3)
+
+	s, v, err = q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want = `SELECT "Customer Key", "Due AS Of" FROM (SELECT "Customer Key", o."Order Date" AS "Due AS Of", CASE WHEN ROW_NUMBER() OVER (PARTITION BY "Customer Key" ORDER BY "Is Due?" DESC) <= $1 /* top ? rows */ THEN 1 ELSE 0 END AS qualified FROM Orders) qf WHERE qualified = 1;`
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{3}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
@@ -3,15 +3,19 @@
 // 2024.08.01
 // Builds SQL query based on the inputs
 //
-// TODO:
-//	- figure out how to cache queries to save iteration of columns and values
+// Queries can be cached by their shape with CacheQueries.
 
 package querybuilder
 
 import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -45,12 +49,48 @@ const (
 	REAR  Limit = 1
 )
 
+// String returns the SQL keyword of the command
+func (c Command) String() string {
+	switch c {
+	case INSERT:
+		return "INSERT"
+	case UPDATE:
+		return "UPDATE"
+	case DELETE:
+		return "DELETE"
+	}
+	return "SELECT"
+}
+
 // errors
 var (
-	ErrNoTableSpecified  = errors.New("table or view was not specified")
-	ErrNoColumnSpecified = errors.New("no columns were specified")
+	ErrNoTableSpecified     = errors.New("table or view was not specified")
+	ErrNoColumnSpecified    = errors.New("no columns were specified")
+	ErrArgumentMismatch     = errors.New("number of placeholders does not match the number of arguments")
+	ErrNotSupported         = errors.New("not supported by the dialect")
+	ErrNoChanges            = errors.New("no columns were changed")
+	ErrRawDenied            = errors.New("raw SQL values are denied")
+	ErrNoFilter             = errors.New("no filters were specified")
+	ErrWarning              = errors.New("builder warning")
+	ErrInvalidIdentifier    = errors.New("invalid identifier")
+	ErrInvalidOption        = errors.New("invalid option")
+	ErrStrict               = errors.New("rejected by strict mode")
+	ErrSuspiciousExpression = errors.New("suspicious expression")
+	ErrTooManyParameters    = errors.New("too many parameters")
+	ErrOrderNotAllowed      = errors.New("ORDER BY is not allowed on the command")
+	ErrGroupNotAllowed      = errors.New("GROUP BY is not allowed on the command")
+	ErrValuesOnDelete       = errors.New("values are not allowed on DELETE")
+	ErrLimitRequiresOrder   = errors.New("OFFSET and FETCH require ORDER BY")
+	ErrInvalidOperator      = errors.New("invalid operator")
+	ErrTenant               = errors.New("query is not scoped to the tenant")
+	ErrParse                = errors.New("cannot parse the query")
+	ErrInvalidValue         = errors.New("value is not allowed")
 )
 
+// FilterFunc returns filters and their arguments from outside providers, such as filterbuilder. The placeholders
+// of the filters start after the offset when the parameters are in sequence.
+type FilterFunc func(offset int, char string, inSeq bool) ([]string, []interface{})
+
 // Option function for QueryBuilder
 type Option func(q *QueryBuilder) error
 type ValueOption func(vo *ValueCompareOption) error
@@ -60,11 +100,25 @@ type ValueCompareOption struct {
 	SQLString   bool        // Sets if the value is an SQL string. When true, this value is enclosed by the database client in single quotes to represent as string
 	Default     interface{} // When set to non-nil, this is the default value when the value encounters a nil
 	MatchToNull interface{} // When the primary value matches with this value, the resulting value will be set to NULL
+	ZeroAsNil   bool        // When true, the Go zero value of the primary value is treated as nil
+	OmitZero    bool        // When true, the column is skipped when the primary value is nil or the Go zero value
+	DefaultUUID bool        // When true, a nil primary value is replaced by a new UUID
+	JSON        bool        // When true, the primary value is marshaled to JSON text
+	DefaultOn   []Command   // Commands the Default value applies to. When empty, it applies to every command
+	MatchOn     []Command   // Commands the MatchToNull value applies to. When empty, it applies to every command
+}
+
+// FilterOption function for filters
+type FilterOption func(fo *FilterCompareOption) error
+
+// FilterCompareOption options for adding filters
+type FilterCompareOption struct {
+	CaseInsensitive bool // When true, the column and the value are compared regardless of case
 }
 
 type QueryColumn struct {
-	Name   string // name of the column
-	Length int    // length of the column
+	Name   string `json:"name"`             // name of the column
+	Length int    `json:"length,omitempty"` // length of the column
 }
 
 type queryValue struct {
@@ -72,15 +126,37 @@ type queryValue struct {
 	value       interface{} // value of the column
 	defvalue    interface{} // default value
 	matchtonull interface{} // when primary value is matched by this value, it will set the value to NULL
+	defon       []Command   // commands the default value applies to, or all commands
+	matchon     []Command   // commands the match to NULL applies to, or all commands
 	sqlstring   bool        // indicates if the value is an SQL string
 	skip        bool        // skip this query value
 	forcenull   bool        // forced to null
+	null        bool        // the resolved value is null
+	zeronil     bool        // zero value is treated as nil
+	omitzero    bool        // skip when the value is the zero value
+	sequence    string      // sequence that generates the value
+	uuid        bool        // a nil value is replaced by a new UUID
+	expr        bool        // the value is an SQL expression generated by the builder
+	generated   bool        // the value is generated by the builder for every row, such as a timestamp or the tenant
 }
 
 type queryFilter struct {
-	expression    string      // Column name or expression of the filter
-	value         interface{} // Value of the filter if the expression is a column name
-	containsvalue bool        // indicates that the filter has a separate value, not a filter expression
+	expression    string        // Column name or expression of the filter
+	value         interface{}   // Value of the filter if the expression is a column name
+	containsvalue bool          // indicates that the filter has a separate value, not a filter expression
+	in            bool          // indicates that the filter matches a list of values
+	op            Operator      // comparison operator of a condition added by Where
+	tree          *Tree         // condition tree added by WhereTree
+	fulltext      []string      // columns of a full-text search added by AddFilterFullText
+	ci            bool          // the column and the value are compared regardless of case
+	escape        bool          // the LIKE pattern escapes its wildcards with LikeEscape
+	scope         bool          // the filter is added by a scope of the builder, such as SoftDelete
+	values        []interface{} // values of an IN filter
+	jsonPath      string        // path of the value in a JSON column added by AddFilterJSONPath
+	quantifier    string        // ANY or ALL of the filters added by AddFilterAny and AddFilterAll
+	sub           *QueryBuilder // subquery of an ANY or ALL filter
+	subSQL        string        // built subquery with ? marks
+	subArgs       []interface{} // arguments of the built subquery
 }
 
 type querySort struct {
@@ -91,6 +167,7 @@ type querySort struct {
 // QueryBuilder is a structure to build SQL queries
 type QueryBuilder struct {
 	TableName              string                                                              // Table or view name of the query
+	TableAlias             string                                                              // Alias of the table in SELECT, UPDATE and DELETE commands
 	CommandType            Command                                                             // Command type
 	Columns                []QueryColumn                                                       // Columns of the query
 	Values                 []queryValue                                                        // Values of the columns
@@ -103,13 +180,75 @@ type QueryBuilder struct {
 	ParameterChar          string                                                              // Gets or sets the character placeholder for prepared statements
 	ParameterInSequence    bool                                                                // Sets of the placeholders will be generated as a sequence of placeholder. Example, for SQL Server, @p0, @p1 @p2
 	SkipNilWriteColumn     bool                                                                // Sets the condition that the Nil columns in an INSERT or UPDATE command would be skipped, instead of being set.
+	OmitZeroWriteColumn    bool                                                                // Sets the condition that the columns with nil or Go zero values in an INSERT or UPDATE command would be skipped
 	ResultLimitPosition    Limit                                                               // The position of the row limiting statement in a query. For SQL Server, the limiting is set at the SELECT clause such as TOP 1. Later versions of SQL server supports OFFSET and FETCH.
 	ResultLimit            string                                                              // The value of the row limit
 	InterpolateTables      bool                                                                // When true, all table name with {} around it will be prepended with schema
+	QuoteTables            bool                                                                // When true, the interpolated schema and table names are enclosed with the ReservedWordEscapeChar
 	Schema                 string                                                              // When the database info is not applied, this value will be used
 	ParameterOffset        int                                                                 // The parameter sequence offset
-	FilterFunc             func(offset int, char string, inSeq bool) ([]string, []interface{}) // returns filter from outside functions like filterbuilder
+	FilterFunc             func(offset int, char string, inSeq bool) ([]string, []interface{}) // returns filter from outside functions like filterbuilder. It is called once per build
+	Dialect                Dialect                                                             // The database dialect. When set, dialect-specific SQL is rendered
+	DialectVersion         int                                                                 // The major version of the database engine. Zero assumes the latest version
+	Conflict               Conflict                                                            // The conflict resolution of INSERT commands, for dialects that support it
+	UpsertKeys             []string                                                            // Key columns of an INSERT command that updates the existing row when the keys conflict
+	ReturnColumns          []string                                                            // Columns returned by INSERT, UPDATE and DELETE commands
+	RowNumberPagination    bool                                                                // Forces Paginate to filter by ROW_NUMBER() for engines without OFFSET and FETCH
+	ArrayIn                bool                                                                // When true, the IN filters of PostgreSQL bind their values as one array with = ANY($1)
+	AllowWriteLimit        bool                                                                // Allows ResultLimit on UPDATE and DELETE commands for SQLite builds compiled with SQLITE_ENABLE_UPDATE_DELETE_LIMIT
+	CacheQueries           bool                                                                // When true, the query is cached by its shape, so that builds of the same shape only collect the arguments
+	MemoizeBuild           bool                                                                // When true, Build returns the result of the previous build until the builder is changed
+	IdentityColumn         string                                                              // Identity column of the table. On SQL Server, an INSERT command with a value for it is wrapped in SET IDENTITY_INSERT
+	VerifyPlaceholders     bool                                                                // When true, the placeholders in the final query, including those from FilterFunc, are counted and checked against the arguments
+	DenyRawValues          bool                                                                // When true, values that are not SQL strings and filter expressions are rejected
+	RequireWhere           bool                                                                // When true, UPDATE and DELETE commands without filters are rejected
+	CompactSQL             bool                                                                // When true, the query is rendered on a single line regardless of the Layout
+	Layout                 Layout                                                              // The whitespace layout of the query. The default is a single line
+	Warnings               Warnings                                                            // Sets how the warnings of the builder are handled
+	StrictMode             bool                                                                // When true, the silent accommodations of the builder, such as AddColumn on DELETE, are returned as errors by Build
 	dbInfo                 *cfg.DatabaseInfo
+	logger                 Logger
+	commentFunc            CommentFunc
+	tags                   map[string]string // tags of the leading comment block
+	tagsFunc               CommentFunc       // tags of the leading comment block from the context
+	metrics                Metrics
+	validator              IdentifierValidator
+	strictErr              error    // first error recorded by strict mode
+	inspect                bool     // raw expressions are inspected
+	inspectMode            Warnings // handling of suspicious raw expressions
+	captureArgs            func(column string, value interface{})
+	schemaCtxKey           interface{}
+	original               map[string]interface{} // snapshot of the original values for change tracking
+	rows                   [][]interface{}        // values of the additional rows of an INSERT command
+	pageOffset             int                    // number of rows skipped by Paginate
+	pageSize               int                    // number of rows of a page
+	qualifyExpr            string                 // QUALIFY expression
+	qualifyArgs            []interface{}          // arguments of the QUALIFY expression
+	memo                   *memoBuild             // last build of a memoizing builder
+	err                    error                  // first error of a builder method, returned by Build
+	createdColumn          string                 // column set to the current time by INSERT commands
+	updatedColumn          string                 // column set to the current time by INSERT and UPDATE commands
+	serverTime             bool                   // the timestamps are set by the database
+	auditUser              UserFunc               // user that creates and updates the rows
+	createdByColumn        string                 // column set to the audit user by INSERT commands
+	updatedByColumn        string                 // column set to the audit user by INSERT and UPDATE commands
+	softDelete             string                 // column set to the time a row is deleted
+	unscoped               bool                   // the scopes of the builder are ignored
+	scopes                 []queryFilter          // default filters added by ScopeFilter
+	unscopedColumns        []string               // columns of the default filters turned off by WithoutScope
+	tokenResolver          TokenResolver          // values of the named tokens of table names
+	buckets                []timeBucket           // time buckets added by GroupByTimeBucket
+	tempTable              bool                   // the table is a temporary table set by TempSource
+	sourceRows             [][]interface{}        // rows of the VALUES source set by SourceValues
+	sourceColumns          []string               // columns of the VALUES source
+	tenantColumn           string                 // column of the tenant of the rows
+	tenantFunc             TenantFunc             // tenant of a build
+	allTenants             bool                   // the query works across tenants
+	moreFilterFuncs        []FilterFunc           // filter functions added by AddFilterFunc
+	converters             []ValueConverter       // converters of the values before they are bound
+	enums                  map[string]enumType    // enum types of the columns by their lower case names
+	timeLocation           *time.Location         // location of the bound times
+	ast                    *astRecorder           // records the clauses of the query rendered for AST
 }
 
 // New builds a new QueryBuilder
@@ -125,7 +264,38 @@ type QueryBuilder struct {
 //	ResultLimit:            ""
 //	InterpolateTables:      true
 //	SkipNilWriteColumn:     false
+//
+// The VerifyPlaceholders, DenyRawValues, RequireWhere, CompactSQL and Layout fields are set from DefaultSettings.
+//
+// The errors of the options are joined and returned by Build. Use NewE to get them right away.
 func New(options ...Option) *QueryBuilder {
+	n := newBuilder()
+	n.err = n.apply(options)
+	return n
+}
+
+// NewE builds a new QueryBuilder like New, but returns the joined errors of the options that fail
+func NewE(options ...Option) (*QueryBuilder, error) {
+	n := newBuilder()
+	if err := n.apply(options); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// apply applies all the options to the builder and returns their joined errors
+func (qb *QueryBuilder) apply(options []Option) error {
+	var errs []error
+	for _, o := range options {
+		if o == nil {
+			continue
+		}
+		errs = append(errs, o(qb))
+	}
+	return joinErrors(errs...)
+}
+
+func newBuilder() *QueryBuilder {
 	n := QueryBuilder{
 		StringEnclosingChar:    `'`,
 		StringEscapeChar:       `\`,
@@ -136,31 +306,64 @@ func New(options ...Option) *QueryBuilder {
 		InterpolateTables:      true,
 		SkipNilWriteColumn:     false,
 	}
-	for _, o := range options {
-		if o == nil {
-			continue
-		}
-		o(&n)
-	}
+	n.applySettings(DefaultSettings())
 	return &n
 }
 
 // WithTableName sets the table name of a query builder
 func WithTableName(name string) Option {
 	return func(q *QueryBuilder) error {
+		if name == "" {
+			return fmt.Errorf("%w: empty table name", ErrInvalidOption)
+		}
 		q.TableName = name
 		return nil
 	}
 }
 
+// WithTableAlias sets the alias of the table of a query builder
+func WithTableAlias(alias string) Option {
+	return func(q *QueryBuilder) error {
+		if alias == "" {
+			return fmt.Errorf("%w: empty table alias", ErrInvalidOption)
+		}
+		q.TableAlias = alias
+		return nil
+	}
+}
+
+// SourceAs sets the table of the query and its alias, such as {Orders} and o, which renders FROM {Orders} o.
+// Columns and filters can refer to the table by the alias, or as {Orders}.column, which is rewritten to the alias
+// when the tables are interpolated. INSERT commands are rendered without the alias.
+func (qb *QueryBuilder) SourceAs(name, alias string) *QueryBuilder {
+	qb.touch()
+	qb.TableName = name
+	qb.TableAlias = alias
+	qb.tempTable = false
+	qb.sourceRows, qb.sourceColumns = nil, nil
+	return qb
+}
+
 // WithSchema sets the schema of a query builder
 func WithSchema(schema string) Option {
 	return func(q *QueryBuilder) error {
+		if schema == "" {
+			return fmt.Errorf("%w: empty schema", ErrInvalidOption)
+		}
 		q.Schema = schema
 		return nil
 	}
 }
 
+// SchemaFromContext sets the context key of the schema used by table interpolation.
+// The schema is taken from the context passed to BuildContext and prevails over the configured schema.
+func SchemaFromContext(key interface{}) Option {
+	return func(q *QueryBuilder) error {
+		q.schemaCtxKey = key
+		return nil
+	}
+}
+
 // WithCommand sets the command of a query builder
 func WithCommand(ct Command) Option {
 	return func(q *QueryBuilder) error {
@@ -172,7 +375,11 @@ func WithCommand(ct Command) Option {
 // WithCommand sets the command of a query builder
 func WithConfig(cfg *cfg.DatabaseInfo) Option {
 	return func(q *QueryBuilder) error {
+		if cfg == nil {
+			return fmt.Errorf("%w: nil database info", ErrInvalidOption)
+		}
 		q.dbInfo = cfg
+		q.Dialect = DialectFromDriver(cfg.DriverName)
 		q.ParameterChar = cfg.ParameterPlaceholder
 		q.ParameterInSequence = cfg.ParameterInSequence
 		if cfg.StringEnclosingChar != nil {
@@ -199,6 +406,22 @@ func SkipNilWrite(skip bool) Option {
 	}
 }
 
+// QuoteTableNames sets the condition to enclose the interpolated schema and table names with the reserved word escape characters
+func QuoteTableNames(quote bool) Option {
+	return func(q *QueryBuilder) error {
+		q.QuoteTables = quote
+		return nil
+	}
+}
+
+// OmitZeroWrite sets the condition to skip columns with nil or Go zero values when writing to table
+func OmitZeroWrite(omit bool) Option {
+	return func(q *QueryBuilder) error {
+		q.OmitZeroWriteColumn = omit
+		return nil
+	}
+}
+
 // IsSqlString sets if the value is an SQL string. When true, this value is enclosed by the database client in single quotes to represent as string
 func IsSqlString(indeed bool) ValueOption {
 	return func(vco *ValueCompareOption) error {
@@ -215,6 +438,47 @@ func Default(value interface{}) ValueOption {
 	}
 }
 
+// DefaultOn is the default value of the column when the value encounters a nil, for the listed commands only
+func DefaultOn(value interface{}, commands ...Command) ValueOption {
+	return func(vco *ValueCompareOption) error {
+		vco.Default = value
+		vco.DefaultOn = commands
+		return nil
+	}
+}
+
+// DefaultOnInsert is the default value of the column when the value encounters a nil on INSERT commands.
+// UPDATE commands write the nil value.
+func DefaultOnInsert(value interface{}) ValueOption {
+	return DefaultOn(value, INSERT)
+}
+
+// TreatZeroAsNil treats the Go zero value of the value, such as 0, "" or a zero time, as nil.
+// The value is then written as NULL, or skipped when SkipNilWriteColumn is true.
+func TreatZeroAsNil() ValueOption {
+	return func(vco *ValueCompareOption) error {
+		vco.ZeroAsNil = true
+		return nil
+	}
+}
+
+// TreatZeroAsValue writes the Go zero value of the value as is. This is the default.
+func TreatZeroAsValue() ValueOption {
+	return func(vco *ValueCompareOption) error {
+		vco.ZeroAsNil = false
+		return nil
+	}
+}
+
+// OmitZero skips the column when the value is nil or the Go zero value, such as 0, "" or a zero time.
+// The value is checked after the Default value is applied.
+func OmitZero() ValueOption {
+	return func(vco *ValueCompareOption) error {
+		vco.OmitZero = true
+		return nil
+	}
+}
+
 // MatchToNull is the condition the primary value matches with this value, the resulting value will be set to NULL
 func MatchToNull(match interface{}) ValueOption {
 	return func(vco *ValueCompareOption) error {
@@ -223,6 +487,31 @@ func MatchToNull(match interface{}) ValueOption {
 	}
 }
 
+// MatchToNullOn sets the value to NULL when it matches with this value, for the listed commands only
+func MatchToNullOn(match interface{}, commands ...Command) ValueOption {
+	return func(vco *ValueCompareOption) error {
+		vco.MatchToNull = match
+		vco.MatchOn = commands
+		return nil
+	}
+}
+
+// MatchToNullOnUpdate sets the value to NULL when it matches with this value on UPDATE commands.
+// INSERT commands write the value as is.
+func MatchToNullOnUpdate(match interface{}) ValueOption {
+	return MatchToNullOn(match, UPDATE)
+}
+
+// CaseInsensitive compares the column and the value of a filter regardless of case. Both sides are wrapped with LOWER(),
+// which behaves the same on every engine but cannot use a plain index on the column. LIKE conditions use ILIKE
+// on the dialects that support it.
+func CaseInsensitive() FilterOption {
+	return func(fo *FilterCompareOption) error {
+		fo.CaseInsensitive = true
+		return nil
+	}
+}
+
 // NewSelect is a shortcut builder for Select queries
 func NewSelect(table string, config cfg.DatabaseInfo) *QueryBuilder {
 	return New(WithTableName(table), WithCommand(SELECT), WithConfig(&config))
@@ -246,17 +535,19 @@ func NewDelete(table string, config cfg.DatabaseInfo) *QueryBuilder {
 // AddColumn adds a column to the builder
 func (qb *QueryBuilder) AddColumn(name string) *QueryBuilder {
 	if qb.CommandType == DELETE {
+		qb.strictFail("AddColumn on DELETE")
 		return qb
 	}
-	return qb.setColumnValue(qb.addColumn(name, 255), nil, true, nil, nil)
+	return qb.setColumnValue(qb.addColumn(name, 255), nil, ValueCompareOption{SQLString: true})
 }
 
 // AddColumnFixed adds a column with specified length
 func (qb *QueryBuilder) AddColumnFixed(name string, length int) *QueryBuilder {
 	if qb.CommandType == DELETE {
+		qb.strictFail("AddColumnFixed on DELETE")
 		return qb
 	}
-	return qb.setColumnValue(qb.addColumn(name, length), nil, true, nil, nil)
+	return qb.setColumnValue(qb.addColumn(name, length), nil, ValueCompareOption{SQLString: true})
 }
 
 // AddValue adds a value. The value options sets certain conditions to evaluate the supplied value
@@ -266,26 +557,58 @@ func (qb *QueryBuilder) AddValue(name string, value interface{}, vcOpts ...Value
 		Default:     nil,
 		MatchToNull: nil,
 	}
+	errs := []error{qb.err}
 	for _, o := range vcOpts {
 		if o == nil {
 			continue
 		}
-		o(&vo)
+		errs = append(errs, o(&vo))
+	}
+	if vo.JSON {
+		var err error
+		value, err = jsonText(value)
+		errs = append(errs, err)
+	}
+	qb.err = joinErrors(errs...)
+	return qb.setColumnValue(qb.addColumn(name, 8000), value, vo)
+}
+
+// AddValuesMap adds a value for each entry of a map. The columns are added in the
+// sorted order of the keys so that the generated SQL is stable. The value options are applied to every entry.
+func (qb *QueryBuilder) AddValuesMap(values map[string]interface{}, vcOpts ...ValueOption) *QueryBuilder {
+	for _, k := range sortedKeys(values) {
+		qb.AddValue(k, values[k], vcOpts...)
+	}
+	return qb
+}
+
+// AddValueSequence adds a value generated by a sequence, rendered inline as NEXTVAL('seq') on PostgreSQL,
+// seq.NEXTVAL on Oracle and NEXT VALUE FOR seq elsewhere. SQLite has no sequences. The sequence name is
+// part of the SQL and is checked by the identifier validator.
+func (qb *QueryBuilder) AddValueSequence(name, sequence string) *QueryBuilder {
+	idx := qb.addColumn(name, 8000)
+	qb.setColumnValue(idx, nil, ValueCompareOption{})
+	for i, v := range qb.Values {
+		if strings.EqualFold(v.column, qb.Columns[idx].Name) {
+			qb.Values[i].sequence = sequence
+		}
 	}
-	return qb.setColumnValue(qb.addColumn(name, 8000), value, vo.SQLString, vo.Default, vo.MatchToNull)
+	return qb
 }
 
 // SetColumnValue - sets the column value
 func (qb *QueryBuilder) SetColumnValue(name string, value interface{}) *QueryBuilder {
 	if qb.CommandType == DELETE {
+		qb.strictFail("SetColumnValue on DELETE")
 		return qb
 	}
-	for i, v := range qb.Values {
-		if strings.EqualFold(name, v.column) {
+	for _, v := range qb.Values {
+		if !strings.EqualFold(name, v.column) {
 			continue
 		}
-		return qb.setColumnValue(i, value, true, nil, nil)
+		return qb.setColumnValue(qb.addColumn(name, 0), value, ValueCompareOption{SQLString: true})
 	}
+	qb.strictFail("SetColumnValue on unknown column " + name)
 	return qb
 }
 
@@ -297,19 +620,119 @@ func (qb *QueryBuilder) Escape(value string) string {
 	return value
 }
 
-// AddFilter adds a filter with value.
-func (qb *QueryBuilder) AddFilter(column string, value interface{}) *QueryBuilder {
+// Col returns a column reference qualified by a table name or alias, such as o.status.
+// Both parts are enclosed with the ReservedWordEscapeChar. Braced table tokens like {Orders}
+// are left unquoted so that they are still resolved by table interpolation.
+func (qb *QueryBuilder) Col(qualifier, column string) string {
+	if qualifier == "" {
+		return qb.quoteIdent(column)
+	}
+	return qb.quoteIdent(qualifier) + "." + qb.quoteIdent(column)
+}
+
+// quoteIdent encloses a single identifier with the reserved word escape characters
+func (qb *QueryBuilder) quoteIdent(name string) string {
+	if name == "" || name == "*" {
+		return name
+	}
+	if strings.HasPrefix(name, "{") && strings.HasSuffix(name, "}") {
+		return name
+	}
+	ec := ParseReserveWordsChars(qb.ReservedWordEscapeChar)
+	if strings.HasPrefix(name, ec[0]) && strings.HasSuffix(name, ec[1]) && len(name) > 1 {
+		return name
+	}
+	return ec[0] + name + ec[1]
+}
+
+// AddFilterFunc adds a filter function that contributes filters along with FilterFunc, such as a tenant scope
+// or feature flag filters. The functions are called in the order they were added, after FilterFunc, and each
+// one receives the offset that follows the placeholders of the previous ones.
+func (qb *QueryBuilder) AddFilterFunc(f FilterFunc) *QueryBuilder {
+	qb.touch()
+	if f != nil {
+		qb.moreFilterFuncs = append(qb.moreFilterFuncs, f)
+	}
+	return qb
+}
+
+// filterFuncs returns FilterFunc and the functions added by AddFilterFunc
+func (qb *QueryBuilder) filterFuncs() []FilterFunc {
+	if qb.FilterFunc == nil {
+		return qb.moreFilterFuncs
+	}
+	return append([]FilterFunc{qb.FilterFunc}, qb.moreFilterFuncs...)
+}
+
+// CaptureArgs sets a function that is called during Build for every bound value, in the order of the returned arguments.
+// The column is the column name or filter expression of the value. Values contributed by FilterFunc,
+// expressions and pagination have no column.
+func (qb *QueryBuilder) CaptureArgs(fn func(column string, value interface{})) *QueryBuilder {
+	qb.touch()
+	qb.captureArgs = fn
+	return qb
+}
+
+func (qb *QueryBuilder) capture(column string, value interface{}) {
+	if qb.captureArgs != nil {
+		qb.captureArgs(column, value)
+	}
+}
+
+// Returning sets the columns returned by an INSERT, UPDATE or DELETE command.
+// It renders RETURNING on PostgreSQL and SQLite, and OUTPUT on SQL Server.
+func (qb *QueryBuilder) Returning(columns ...string) *QueryBuilder {
+	qb.touch()
+	qb.ReturnColumns = append(qb.ReturnColumns, columns...)
+	return qb
+}
+
+// AddFilter adds a filter with value. The filter options set how the value is compared.
+func (qb *QueryBuilder) AddFilter(column string, value interface{}, foOpts ...FilterOption) *QueryBuilder {
+	qb.touch()
+	var fo FilterCompareOption
+	errs := []error{qb.err}
+	for _, o := range foOpts {
+		if o == nil {
+			continue
+		}
+		errs = append(errs, o(&fo))
+	}
+	qb.err = joinErrors(errs...)
 	qb.Filter = append(
 		qb.Filter,
 		queryFilter{
 			expression: column,
 			value:      value,
+			ci:         fo.CaseInsensitive,
 		})
 	return qb
 }
 
+// AddFilterIn adds a filter that matches the column against a list of values, such as UserKey IN (?, ?, ?).
+// An empty list matches no rows.
+func (qb *QueryBuilder) AddFilterIn(column string, values ...interface{}) *QueryBuilder {
+	qb.touch()
+	qb.Filter = append(qb.Filter, queryFilter{
+		expression: column,
+		in:         true,
+		values:     values,
+	})
+	return qb
+}
+
+// AddFiltersMap adds an equality filter for each entry of a map, or an IS NULL filter when the value is nil.
+// The filters are added in the sorted order of the keys so that the generated SQL is stable.
+func (qb *QueryBuilder) AddFiltersMap(filters map[string]interface{}) *QueryBuilder {
+	for _, k := range sortedKeys(filters) {
+		qb.AddFilter(k, filters[k])
+	}
+	return qb
+}
+
 // AddFilterExp adds a specific filter expression that could not be done with AddFilter
 func (qb *QueryBuilder) AddFilterExp(expr string) *QueryBuilder {
+	qb.touch()
 	qb.Filter = append(qb.Filter, queryFilter{
 		expression:    expr,
 		value:         nil,
@@ -320,222 +743,619 @@ func (qb *QueryBuilder) AddFilterExp(expr string) *QueryBuilder {
 
 // AddOrder - adds a column to order by into the QueryBuilder for both BuildString() and BuildDataHelper() function.
 func (qb *QueryBuilder) AddOrder(column string, order Sort) *QueryBuilder {
+	qb.touch()
 	qb.Order = append(qb.Order, querySort{column: column, order: order})
 	return qb
 }
 
-// AddGroup - adds a group by clause
+// AddGroup - adds a group by clause. The GROUP BY clause is rendered before the ORDER BY clause.
 func (qb *QueryBuilder) AddGroup(group string) *QueryBuilder {
+	qb.touch()
 	qb.Group = append(qb.Group, group)
 	return qb
 }
 
-// Build an SQL string with corresponding values
+// Build an SQL string with corresponding values. Build advances the ParameterOffset, so a builder
+// must not be built from more than one goroutine at once. Build a Snapshot instead.
 func (qb *QueryBuilder) Build() (query string, args []interface{}, err error) {
+	return qb.BuildContext(context.Background())
+}
+
+// BuildContext builds an SQL string with corresponding values. Request-scoped settings,
+// such as the schema set by SchemaFromContext, are taken from the context.
+func (qb *QueryBuilder) BuildContext(ctx context.Context) (query string, args []interface{}, err error) {
+	if !qb.MemoizeBuild {
+		return qb.build(ctx)
+	}
+	if query, args, ok := qb.memoized(ctx); ok {
+		return query, args, nil
+	}
+	return qb.memoizedBuild(ctx)
+}
+
+func (qb *QueryBuilder) build(ctx context.Context) (query string, args []interface{}, err error) {
+	if m := qb.observer(); m != nil {
+		start := time.Now()
+		defer func() {
+			m.ObserveBuild(qb.CommandType, time.Since(start), len(args), err)
+		}()
+	}
 	if qb.TableName == "" {
 		return "", nil, ErrNoTableSpecified
 	}
 	if len(qb.Columns) == 0 && qb.CommandType != DELETE {
 		return "", nil, ErrNoColumnSpecified
 	}
-	// get real values of qb.Values and set them back
-	for i := range qb.Values {
-		qb.Values[i].value = realValue(qb.Values[i].value)
-		qb.Values[i].defvalue = realValue(qb.Values[i].defvalue)
-		qb.Values[i].matchtonull = realValue(qb.Values[i].matchtonull)
+	if qb.err != nil {
+		return "", nil, qb.err
+	}
+	if err := qb.validateIdentifiers(); err != nil {
+		return "", nil, err
+	}
+	if err := qb.strictCheck(); err != nil {
+		return "", nil, err
+	}
+	if err := qb.checkClauses(); err != nil {
+		return "", nil, err
+	}
+	if err := qb.inspectExpressions(); err != nil {
+		return "", nil, err
+	}
+	tenant, err := qb.tenant(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	// The query is rendered from a copy with the resolved values, so that the builder is left unchanged
+	w := qb.resolved(ctx)
+	w.applyTenant(tenant)
+	if query, args, err = w.render(ctx); err != nil {
+		return "", nil, err
+	}
+	qb.ParameterOffset = w.ParameterOffset
+	return
+}
+
+// resolved returns a copy of the builder with the real values of the values and filters
+func (qb *QueryBuilder) resolved(ctx context.Context) *QueryBuilder {
+	w := *qb
+	w.Values = make([]queryValue, len(qb.Values))
+	for i, v := range qb.Values {
+		v.value = realValue(v.value)
+		if v.sqlstring && len(qb.converters) > 0 {
+			v.value = qb.convert(v.column, v.value)
+		}
+		if v.zeronil && isZero(v.value) {
+			v.value = nil
+		}
+		if v.uuid && isNil(v.value) {
+			v.value, v.sqlstring = qb.newUUID()
+			v.expr = !v.sqlstring
+		}
+		// the next value of a sequence is rendered inline for the dialect
+		if v.sequence != "" {
+			v.value = qb.NextVal(v.sequence)
+			v.sqlstring = false
+			v.expr = true
+		}
+		v.defvalue = realValue(v.defvalue)
+		v.matchtonull = realValue(v.matchtonull)
+		// the default and the match to NULL of other commands do not apply
+		if !hasCommand(v.defon, qb.CommandType) {
+			v.defvalue = nil
+		}
+		if !hasCommand(v.matchon, qb.CommandType) {
+			v.matchtonull = nil
+		}
+		w.Values[i] = v
+	}
+	w.Filter = make([]queryFilter, len(qb.Filter))
+	for i, f := range qb.Filter {
+		f.value = realValue(f.value)
+		if !f.containsvalue && len(qb.converters) > 0 {
+			f.value = qb.convert(f.expression, f.value)
+		}
+		if f.tree != nil {
+			t := f.tree.copy(realValue)
+			qb.convertTree(&t)
+			f.tree = &t
+		}
+		if len(f.values) > 0 {
+			vals := make([]interface{}, len(f.values))
+			for j, fv := range f.values {
+				vals[j] = qb.convert(f.expression, realValue(fv))
+			}
+			f.values = vals
+		}
+		w.Filter[i] = f
 	}
+	w.resolveValues()
+	w.addTimestamps()
+	w.addAuditUser(ctx)
+	w.applyScopes()
+	w.applySoftDelete()
+	return &w
+}
 
-	// get real values of filter values and set them back
-	for i := range qb.Filter {
-		qb.Filter[i].value = realValue(qb.Filter[i].value)
+// render renders the query of a builder with resolved values
+func (qb *QueryBuilder) render(ctx context.Context) (query string, args []interface{}, err error) {
+	if err := qb.checkRaw(); err != nil {
+		return "", nil, err
+	}
+	if err := qb.checkArrays(); err != nil {
+		return "", nil, err
+	}
+	if err := qb.checkEnums(); err != nil {
+		return "", nil, err
+	}
+	if err := qb.buildSubqueries(ctx); err != nil {
+		return "", nil, err
+	}
+	if err := qb.renderBuckets(); err != nil {
+		return "", nil, err
+	}
+
+	// A cached query of the same shape only needs the arguments
+	sch := qb.schemaName(ctx)
+	var key string
+	// the tables resolved by a token resolver, such as per-tenant databases, are not part of the shape
+	if qb.CacheQueries && len(qb.filterFuncs()) == 0 && qb.tokenResolver == nil {
+		key = qb.shapeKey(sch)
+		if cq, ok := queryCache.Load(key); ok {
+			c := cq.(cachedQuery)
+			if args, err = qb.collectArgs(nil); err != nil {
+				return "", nil, err
+			}
+			query = c.query
+			if qb.commentFunc != nil {
+				query = appendComment(query, qb.commentFunc(ctx))
+			}
+			query = qb.prependTags(ctx, query)
+			qb.ParameterOffset = c.next
+			return
+		}
 	}
 
 	// Auto attach schema
 	var sb strings.Builder
+	sb.Grow(qb.sizeHint())
 	tbn := qb.TableName
+	if qb.tempTable {
+		tbn = qb.TempTableName(tbn)
+	}
+	if qb.TableAlias != "" && qb.CommandType != INSERT {
+		tbn += " " + qb.TableAlias
+	}
+	paging := qb.pagingMode()
+	qualify := qb.qualifyExpr != "" && qb.CommandType == SELECT
+	emulateQualify := qualify && !qb.Dialect.Supports(QUALIFY)
+	if emulateQualify && paging == pageRowNumber {
+		return "", nil, fmt.Errorf("%w: QUALIFY with ROW_NUMBER() pagination", ErrNotSupported)
+	}
 	switch qb.CommandType {
 	case SELECT:
 		sb.WriteString("SELECT ")
-		if len(qb.ResultLimit) > 0 && qb.ResultLimitPosition == FRONT {
+		if len(qb.ResultLimit) > 0 && qb.ResultLimitPosition == FRONT && paging == pageNone && !emulateQualify {
 			sb.WriteString(" TOP " + qb.ResultLimit + " ")
 		}
+		if paging == pageRowNumber {
+			ob := qb.orderList()
+			if ob == "" {
+				ob = "(SELECT NULL)"
+			}
+			sb.WriteString("ROW_NUMBER() OVER (ORDER BY " + ob + ") AS rn, ")
+		}
 	case INSERT:
-		sb.WriteString("INSERT INTO " + tbn + " (")
+		ins, err := qb.insertClause()
+		if err != nil {
+			return "", nil, err
+		}
+		sb.WriteString(ins + " " + tbn + " (")
 	case UPDATE:
 		sb.WriteString("UPDATE " + tbn + " SET ")
 	case DELETE:
 		sb.WriteString("DELETE \rFROM " + tbn)
 	}
 
+	// returning clause, rendered at the dialect's position
+	output, returning, err := qb.returningClause()
+	if err != nil {
+		return "", nil, err
+	}
+
 	// build columns (with placeholder for update )
 	cma := ""
 	pchar := ""
 	paramcnt := qb.ParameterOffset
 	columncnt := 0
+	phcnt := 0 // placeholders emitted by the builder
 
-	for idx, v := range qb.Values {
-		qb.Values[idx].forcenull = false
-		isnl := isNil(v.value)
-		// If value is nil, get defvalue
-		if isnl && !isNil(v.defvalue) {
-			v.value = v.defvalue
-			isnl = false
-		}
-		// If matchtonull is true, column value is nil
-		if !isnl && !isNil(v.matchtonull) && v.matchtonull == v.value {
-			isnl = true
-			qb.Values[idx].forcenull = true
-			qb.Values[idx].sqlstring = true
-		}
-		// Skip columns to render if the SkipNilWriteColumn is true and value is nil
-		qb.Values[idx].skip = qb.SkipNilWriteColumn && isnl
+	for _, v := range qb.Values {
+		isnl := v.null
 		switch qb.CommandType {
 		case SELECT:
-			sb.WriteString(cma + v.column)
+			sb.WriteString(cma)
+			sb.WriteString(v.column)
 			cma = ", "
 			columncnt++
+			qb.record("SELECT", v.column, v.column, phcnt, phcnt)
 		case INSERT:
-			if qb.Values[idx].skip && !qb.Values[idx].forcenull {
+			if v.skip && !v.forcenull {
 				break
 			}
-			sb.WriteString(cma + v.column)
+			sb.WriteString(cma)
+			sb.WriteString(v.column)
 			cma = ", "
 			columncnt++
 		case UPDATE:
-			if qb.Values[idx].skip && !qb.Values[idx].forcenull {
+			if v.skip && !v.forcenull {
 				break
 			}
-			sb.WriteString(cma + v.column)
-			pchar = " = "
+			sb.WriteString(cma)
+			start, ph := sb.Len(), phcnt
+			sb.WriteString(v.column)
+			sb.WriteString(" = ")
 			if isnl {
-				pchar += "NULL"
+				sb.WriteString("NULL")
 			} else {
 				if v.sqlstring {
-					pchar += qb.ParameterChar
-					if qb.ParameterInSequence {
-						paramcnt++
-						pchar += strconv.Itoa(paramcnt)
-					}
+					sb.WriteString(qb.enumCast(v.column, qb.placeholder(&paramcnt)))
+					phcnt++
 				} else {
 					switch t := v.value.(type) {
 					case string:
-						pchar += t
+						sb.WriteString(t)
 					case int:
-						pchar += strconv.Itoa(t)
+						sb.WriteString(strconv.Itoa(t))
 					case int64:
-						pchar += strconv.FormatInt(t, 10)
+						sb.WriteString(strconv.FormatInt(t, 10))
 					case bool:
 						if t {
-							pchar += "1"
+							sb.WriteString("1")
 						} else {
-							pchar += "0"
+							sb.WriteString("0")
 						}
 					case float32:
-						pchar += strconv.FormatFloat(float64(t), 'E', -1, 32)
+						sb.WriteString(strconv.FormatFloat(float64(t), 'E', -1, 32))
 					case float64:
-						pchar += strconv.FormatFloat(t, 'E', -1, 64)
+						sb.WriteString(strconv.FormatFloat(t, 'E', -1, 64))
+					default:
+						if qb.StrictMode {
+							return "", nil, fmt.Errorf("%w: raw value of %s is a %T", ErrStrict, v.column, t)
+						}
 					}
 				}
 			}
-			sb.WriteString(pchar)
 			cma = ", "
 			columncnt++
+			if qb.ast != nil {
+				qb.record("SET", sb.String()[start:], v.column, ph, phcnt)
+			}
 		}
 	}
 
+	if qb.CommandType == UPDATE && qb.original != nil && columncnt == 0 {
+		return "", nil, ErrNoChanges
+	}
+
+	// The emulated QUALIFY is computed as a column
+	if emulateQualify {
+		qe, n := qb.bindMarks(qb.qualifyExpr, &paramcnt)
+		phcnt += n
+		sb.WriteString(cma + "CASE WHEN " + qe + " THEN 1 ELSE 0 END AS qualified")
+	}
+
 	// Append table name for SELECT
 	if qb.CommandType == SELECT {
+		if qb.sourceColumns != nil {
+			tbn = qb.valuesSource(&paramcnt, &phcnt)
+		}
 		sb.WriteString(" \rFROM " + tbn)
 	}
 
 	// build value place holder for insert
 	if qb.CommandType == INSERT {
-		cma = ""
-		pchar = ""
-		inscnt := 0
-		q := make([]string, columncnt)
-		for _, v := range qb.Values {
+		inscols := make([]string, 0, columncnt)
+		insvals := make([]string, 0, columncnt)
+		written := make([]int, 0, columncnt)
+		for idx, v := range qb.Values {
 			if v.skip && !v.forcenull {
 				continue
 			}
+			written = append(written, idx)
+			ph := phcnt
 			pchar = "NULL"
 			if !isNil(v.value) && !v.forcenull {
 				if !v.sqlstring {
-					pchar, _ = v.value.(string)
-				} else {
-					pchar = qb.ParameterChar
-					if qb.ParameterInSequence {
-						paramcnt++
-						pchar += strconv.Itoa(paramcnt)
+					var ok bool
+					if pchar, ok = v.value.(string); !ok && qb.StrictMode {
+						return "", nil, fmt.Errorf("%w: raw value of %s is not a string", ErrStrict, v.column)
 					}
+				} else {
+					pchar = qb.enumCast(v.column, qb.placeholder(&paramcnt))
+					phcnt++
+				}
+			}
+			inscols = append(inscols, v.column)
+			insvals = append(insvals, pchar)
+			qb.record("VALUES", pchar, v.column, ph, phcnt)
+		}
+		rows, err := qb.rowsClause(written, &paramcnt, &phcnt)
+		if err != nil {
+			return "", nil, err
+		}
+		merge, ups := false, ""
+		if len(qb.UpsertKeys) > 0 {
+			if merge, ups, err = qb.upsertClause(tbn, inscols, insvals); err != nil {
+				return "", nil, err
+			}
+		}
+		if merge {
+			if len(qb.rows) > 0 {
+				return "", nil, fmt.Errorf("%w: multi-row MERGE", ErrNotSupported)
+			}
+			sb.Reset()
+			sb.WriteString(ups)
+			sb.WriteString(output)
+		} else {
+			sb.WriteString(")")
+			sb.WriteString(output)
+			sb.WriteString(" VALUES (")
+			for i, iv := range insvals {
+				if i > 0 {
+					sb.WriteString(",")
 				}
+				sb.WriteString(iv)
 			}
-			q[inscnt] = cma + pchar
-			cma = ","
-			inscnt++
+			sb.WriteString(")")
+			sb.WriteString(rows)
+			sb.WriteString(ups)
 		}
-		sb.WriteString(") VALUES (" + strings.Join(q, "") + ")")
 	}
 
-	// build filter parameters for SELECT, UPDATE and DELETE
+	if qb.CommandType == UPDATE || qb.CommandType == DELETE {
+		sb.WriteString(output)
+	}
+
+	// build filter parameters for SELECT, UPDATE and DELETE.
+	// Each filter function is called once, and its arguments are kept for the argument list
+	var fbargs []interface{}
 	if qb.CommandType == SELECT || qb.CommandType == UPDATE || qb.CommandType == DELETE {
-		cma = ""
-		var tsb strings.Builder
+		cma = "\r\t WHERE "
+		filtered := false
 		for _, c := range qb.Filter {
-			if !isNil(c.value) {
-				pchar = qb.ParameterChar
-				if qb.ParameterInSequence {
-					paramcnt++
-					pchar += strconv.Itoa(paramcnt)
+			sb.WriteString(cma)
+			start, ph := sb.Len(), phcnt
+			if c.in {
+				sb.WriteString(qb.inClause(c, &paramcnt, &phcnt))
+			} else if c.fulltext != nil {
+				sb.WriteString(qb.fullTextClause(c, &paramcnt, &phcnt))
+			} else if c.tree != nil {
+				sb.WriteString(qb.treeClause(*c.tree, &paramcnt, &phcnt))
+			} else if c.quantifier != "" {
+				sb.WriteString(qb.quantifiedClause(c, &paramcnt, &phcnt))
+			} else if c.op != "" {
+				sb.WriteString(qb.condClause(c, &paramcnt, &phcnt))
+			} else if !isNil(c.value) && c.ci {
+				sb.WriteString("LOWER(" + c.expression + ") = LOWER(" + qb.placeholder(&paramcnt) + ")")
+				phcnt++
+			} else if c.jsonPath != "" {
+				sb.WriteString(qb.jsonValue(c.expression, c.jsonPath))
+				if isNil(c.value) {
+					sb.WriteString(" IS NULL")
+				} else {
+					sb.WriteString(" = " + qb.placeholder(&paramcnt))
+					phcnt++
 				}
-				tsb.WriteString(cma + c.expression + " = " + pchar)
+			} else if !isNil(c.value) {
+				sb.WriteString(c.expression)
+				sb.WriteString(" = ")
+				sb.WriteString(qb.enumCast(c.expression, qb.placeholder(&paramcnt)))
+				phcnt++
 			} else {
-				tsb.WriteString(cma + c.expression)
+				sb.WriteString(c.expression)
 				if !c.containsvalue {
-					tsb.WriteString(" IS NULL")
+					sb.WriteString(" IS NULL")
 				}
 			}
 			cma = "\r\t\t AND "
+			filtered = filtered || !c.scope
+			if qb.ast != nil {
+				qb.record("WHERE", sb.String()[start:], c.column(), ph, phcnt)
+			}
 		}
-		if qb.FilterFunc != nil {
-			fbs, _ := qb.FilterFunc(paramcnt, qb.ParameterChar, qb.ParameterInSequence)
-			if len(fbs) > 0 {
-				for _, fb := range fbs {
-					tsb.WriteString(cma + fb)
-					cma = "\r\t\t AND "
-				}
+		for _, ff := range qb.filterFuncs() {
+			fbs, fa := ff(paramcnt, qb.ParameterChar, qb.ParameterInSequence)
+			if len(fbs) == 0 {
+				continue
+			}
+			fbargs = append(fbargs, fa...)
+			for _, fb := range fbs {
+				sb.WriteString(cma)
+				sb.WriteString(fb)
+				cma = "\r\t\t AND "
+				qb.recordFunc(fb, phcnt)
+			}
+			filtered = true
+			// the placeholders of the filter function take up the sequence
+			if qb.ParameterInSequence {
+				paramcnt += len(fa)
 			}
 		}
-		if tsb.Len() > 0 {
-			sb.WriteString("\r\t WHERE " + tsb.String())
+		if !filtered && qb.RequireWhere && qb.CommandType != SELECT {
+			return "", nil, fmt.Errorf("%w: %s on %s", ErrNoFilter, qb.CommandType, qb.TableName)
 		}
 	}
 
-	// build order bys
-	if len(qb.Order) > 0 {
-		sb.WriteString(" ORDER BY ")
-		cma = ""
-		for _, v := range qb.Order {
-			sb.WriteString(cma + v.column)
-			if v.order == ASC {
-				sb.WriteString(" ASC")
-			} else {
-				sb.WriteString(" DESC")
+	// build group by. GROUP BY always comes before ORDER BY, as SQL requires
+	if len(qb.Group) > 0 {
+		sb.WriteString(" GROUP BY " + strings.Join(qb.Group, ", "))
+	}
+	if qualify {
+		if emulateQualify {
+			inner := sb.String()
+			sb.Reset()
+			// The row limit applies to the qualified rows, so TOP moves to the outer query
+			sb.WriteString("SELECT ")
+			if len(qb.ResultLimit) > 0 && qb.ResultLimitPosition == FRONT && paging == pageNone {
+				sb.WriteString("TOP " + qb.ResultLimit + " ")
 			}
-			cma = ", "
+			sb.WriteString(qb.qualifyColumns() + " FROM (" + inner + ") qf WHERE qualified = 1")
+		} else {
+			qe, n := qb.bindMarks(qb.qualifyExpr, &paramcnt)
+			phcnt += n
+			sb.WriteString(" QUALIFY " + qe)
 		}
 	}
-	// build group by
-	if len(qb.Group) > 0 {
-		sb.WriteString(" GROUP BY " + strings.Join(qb.Group, ", "))
+	// build order bys. The ROW_NUMBER() pagination orders in the window instead
+	if len(qb.Order) > 0 && paging != pageRowNumber {
+		sb.WriteString(" ORDER BY " + qb.orderList())
 	}
-	if len(qb.ResultLimit) > 0 && qb.ResultLimitPosition == REAR {
+	rownum := false
+	if paging == pageRowNumber {
+		var from, to string
+		for _, p := range []*string{&from, &to} {
+			*p = qb.ParameterChar
+			if qb.ParameterInSequence {
+				paramcnt++
+				*p += strconv.Itoa(paramcnt)
+			}
+			phcnt++
+		}
+		inner := sb.String()
+		sb.Reset()
+		sb.WriteString("SELECT * FROM (" + inner + ") pg WHERE rn BETWEEN " + from + " AND " + to + " ORDER BY rn")
+	} else if paging != pageNone {
+		sb.WriteString(qb.pageClause(paging, len(qb.Order) > 0))
+	} else if len(qb.ResultLimit) > 0 && qb.Dialect == ORACLE {
+		if qb.CommandType != SELECT {
+			return "", nil, fmt.Errorf("%w: row limit on %s", ErrNotSupported, qb.CommandType)
+		}
+		// Oracle 12c introduced FETCH FIRST. Older versions filter by ROWNUM
+		if qb.DialectVersion == 0 || qb.DialectVersion >= 12 {
+			sb.WriteString(" FETCH FIRST " + qb.ResultLimit + " ROWS ONLY")
+		} else {
+			rownum = true
+		}
+	} else if len(qb.ResultLimit) > 0 && qb.ResultLimitPosition == REAR {
+		if qb.Dialect == SQLITE && (qb.CommandType == UPDATE || qb.CommandType == DELETE) && !qb.AllowWriteLimit {
+			return "", nil, fmt.Errorf("%w: LIMIT on UPDATE and DELETE", ErrNotSupported)
+		}
 		sb.WriteString(" LIMIT " + qb.ResultLimit)
 	}
-	sb.WriteString(";")
+	sb.WriteString(returning)
+	if rownum {
+		inner := sb.String()
+		sb.Reset()
+		sb.WriteString("SELECT * FROM (" + inner + ") WHERE ROWNUM <= " + qb.ResultLimit)
+	}
+	// Oracle drivers reject the statement terminator
+	if qb.Dialect != ORACLE {
+		sb.WriteString(";")
+	}
+	if qb.identityInsert() {
+		inner := sb.String()
+		sb.Reset()
+		sb.WriteString("SET IDENTITY_INSERT " + tbn + " ON;\r" + inner + "\rSET IDENTITY_INSERT " + tbn + " OFF;")
+	}
 
 	// build values
-	args = make([]interface{}, 0, 15)
+	if args, err = qb.collectArgs(fbargs); err != nil {
+		return "", nil, err
+	}
+	// check that every placeholder emitted by the builder has an argument
+	if phcnt != len(args)-len(fbargs) {
+		return "", nil, fmt.Errorf("%w: %d placeholders, %d arguments", ErrArgumentMismatch, phcnt, len(args)-len(fbargs))
+	}
+
+	query = sb.String()
+	query = qb.layout(query)
+	if qb.VerifyPlaceholders {
+		if n := countPlaceholders(query, qb.ParameterChar, qb.ParameterInSequence, qb.StringEnclosingChar); n != len(args) {
+			return "", nil, fmt.Errorf("%w: %d placeholders, %d arguments", ErrArgumentMismatch, n, len(args))
+		}
+	}
+	if qb.InterpolateTables {
+		// replace table names marked with {table}
+		if qb.QuoteTables {
+			query = InterpolateTableQuotedFunc(query, sch, qb.ReservedWordEscapeChar, qb.tokenResolver)
+		} else {
+			query = InterpolateTableFunc(query, sch, qb.tokenResolver)
+		}
+	}
+	if key != "" {
+		qb.cacheQuery(key, cachedQuery{query: query, next: paramcnt})
+	}
+	if qb.commentFunc != nil {
+		query = appendComment(query, qb.commentFunc(ctx))
+	}
+	query = qb.prependTags(ctx, query)
+	qb.ParameterOffset = paramcnt
+	return
+}
+
+// hasCommand reports if the command is in the list of commands. An empty list has every command.
+func hasCommand(commands []Command, c Command) bool {
+	if len(commands) == 0 {
+		return true
+	}
+	for _, cmd := range commands {
+		if cmd == c {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveValues applies the defaults, the matches to NULL and the skip conditions to the values
+func (qb *QueryBuilder) resolveValues() {
+	for idx, v := range qb.Values {
+		qb.Values[idx].forcenull = false
+		isnl := isNil(v.value)
+		// If value is nil, get defvalue
+		if isnl && !isNil(v.defvalue) {
+			v.value = v.defvalue
+			qb.Values[idx].value = v.defvalue
+			isnl = false
+		}
+		// Zero values are omitted after the default is applied
+		omit := (v.omitzero || qb.OmitZeroWriteColumn) && isZero(v.value)
+		// If matchtonull is true, column value is nil
+		if !isnl && !isNil(v.matchtonull) && v.matchtonull == v.value {
+			isnl = true
+			qb.Values[idx].forcenull = true
+			qb.Values[idx].sqlstring = true
+		}
+		qb.Values[idx].null = isnl
+		// Skip columns to render if the SkipNilWriteColumn is true and value is nil
+		qb.Values[idx].skip = (qb.SkipNilWriteColumn && isnl) || omit
+		// Skip unchanged columns when tracking changes
+		if qb.CommandType == UPDATE && qb.original != nil && !qb.changed(v.column, v.value) {
+			qb.Values[idx].skip = true
+			qb.Values[idx].forcenull = false
+		}
+	}
+}
+
+// collectArgs returns the arguments of the query in the order of their placeholders.
+// The arguments of the filter functions follow the filter values.
+func (qb *QueryBuilder) collectArgs(fbargs []interface{}) ([]interface{}, error) {
+	args := make([]interface{}, 0, qb.argCap()+len(fbargs))
+	add := func(column string, a interface{}) {
+		a = qb.normalizeTime(a)
+		args = append(args, a)
+		qb.capture(column, a)
+	}
+	paging := qb.pagingMode()
+	qualify := qb.qualifyExpr != "" && qb.CommandType == SELECT
+	emulateQualify := qualify && !qb.Dialect.Supports(QUALIFY)
+	if emulateQualify {
+		for _, a := range qb.qualifyArgs {
+			add("", a)
+		}
+	}
+	if qb.CommandType == SELECT {
+		qb.sourceArgs(add)
+	}
 	for _, v := range qb.Values {
 		if v.skip ||
 			!v.sqlstring ||
@@ -544,37 +1364,182 @@ func (qb *QueryBuilder) Build() (query string, args []interface{}, err error) {
 			v.forcenull {
 			continue
 		}
-		args = append(args, v.value)
+		add(v.column, v.value)
+		if err := qb.warnEmptyString(v.column, v.value); err != nil {
+			return nil, err
+		}
+	}
+	// build values of the additional rows
+	if qb.CommandType == INSERT {
+		args = qb.rowArgs(args)
 	}
 	// build filter values
-	for _, v := range qb.Filter {
-		if (qb.CommandType == SELECT || qb.CommandType == UPDATE || qb.CommandType == DELETE) && !isNil(v.value) {
-			args = append(args, v.value)
+	if qb.CommandType == SELECT || qb.CommandType == UPDATE || qb.CommandType == DELETE {
+		for _, v := range qb.Filter {
+			if v.tree != nil {
+				var err error
+				v.tree.conds(func(c Cond) {
+					if isNil(c.Value) || err != nil {
+						return
+					}
+					if c.Operator == OpIn {
+						for _, iv := range inValues(c.Value) {
+							if !isNil(iv) {
+								add(c.Column, iv)
+							}
+						}
+						return
+					}
+					add(c.Column, c.Value)
+					if c.Operator == OpNullSafeEq && qb.nullSafeExpanded() {
+						add(c.Column, c.Value)
+					}
+					err = qb.warnEmptyString(c.Column, c.Value)
+				})
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if qb.arrayIn(v) {
+				add(v.expression, inArray(v.values))
+				continue
+			}
+			if v.in {
+				for _, iv := range v.values {
+					if !isNil(iv) {
+						add(v.expression, iv)
+					}
+				}
+				continue
+			}
+			if v.sub != nil {
+				for _, a := range v.subArgs {
+					add(v.expression, a)
+				}
+				continue
+			}
+			if isNil(v.value) {
+				continue
+			}
+			add(v.expression, v.value)
+			if v.op == OpNullSafeEq && qb.nullSafeExpanded() {
+				add(v.expression, v.value)
+			}
+			if err := qb.warnEmptyString(v.expression, v.value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, a := range fbargs {
+		add("", a)
+	}
+	if qualify && !emulateQualify {
+		for _, a := range qb.qualifyArgs {
+			add("", a)
+		}
+	}
+	if paging == pageRowNumber {
+		add("", qb.pageOffset+1)
+		add("", qb.pageOffset+qb.pageSize)
+	}
+	return args, nil
+}
+
+// sizeHint estimates the length of the rendered query from the table, columns, filters and clauses
+func (qb *QueryBuilder) sizeHint() int {
+	n := 64 + len(qb.TableName) + len(qb.ResultLimit) + len(qb.qualifyExpr)
+	for _, v := range qb.Values {
+		// the column, the separator and the placeholder or raw value
+		n += len(v.column) + 8
+		if s, ok := v.value.(string); ok && !v.sqlstring {
+			n += len(s)
 		}
 	}
-	if qb.FilterFunc != nil {
-		fbs, fbargs := qb.FilterFunc(paramcnt, qb.ParameterChar, qb.ParameterInSequence)
-		if len(fbs) > 0 {
-			args = append(args, fbargs...)
+	n += len(qb.rows) * len(qb.Values) * 6
+	for _, f := range qb.Filter {
+		n += len(f.expression) + 16 + len(f.values)*6
+		if f.tree != nil {
+			f.tree.conds(func(c Cond) { n += len(c.Column) + 16 })
 		}
 	}
+	for _, o := range qb.Order {
+		n += len(o.column) + 8
+	}
+	for _, c := range qb.Group {
+		n += len(c) + 2
+	}
+	for _, c := range qb.ReturnColumns {
+		n += len(c) + 4
+	}
+	return n
+}
 
-	query = sb.String()
-	if qb.InterpolateTables {
-		sch := ``
-		// if there is a dbinfo, get the schema
-		if qb.dbInfo != nil {
-			sch = qb.dbInfo.Schema
+// argCap returns the most arguments the query can have, without those of FilterFunc
+func (qb *QueryBuilder) argCap() int {
+	n := len(qb.Values) + len(qb.rows)*len(qb.Values) + len(qb.qualifyArgs) + 2
+	for _, f := range qb.Filter {
+		n += 1 + len(f.values)
+		if f.tree != nil {
+			f.tree.conds(func(c Cond) { n += 1 + len(inValues(c.Value)) })
 		}
-		// If there is a schema defined, it will prevail
-		if qb.Schema != "" {
-			sch = qb.Schema
+	}
+	return n
+}
+
+// schemaName returns the schema of the interpolated tables
+func (qb *QueryBuilder) schemaName(ctx context.Context) string {
+	sch := ``
+	// if there is a dbinfo, get the schema
+	if qb.dbInfo != nil {
+		sch = qb.dbInfo.Schema
+	}
+	// If there is a schema defined, it will prevail
+	if qb.Schema != "" {
+		sch = qb.Schema
+	}
+	// A schema from the request context prevails over all
+	if qb.schemaCtxKey != nil {
+		if cs, ok := ctx.Value(qb.schemaCtxKey).(string); ok && cs != "" {
+			sch = cs
 		}
-		// replace table names marked with {table}
-		query = InterpolateTable(query, sch)
 	}
-	qb.ParameterOffset = paramcnt
-	return
+	return sch
+}
+
+// checkClauses returns the error of a clause that the command cannot render
+func (qb *QueryBuilder) checkClauses() error {
+	if len(qb.Order) > 0 && qb.CommandType != SELECT {
+		// MySQL, and SQLite builds with SQLITE_ENABLE_UPDATE_DELETE_LIMIT, order the rows of a limited write
+		writeOrder := qb.Dialect == MYSQL || qb.Dialect == SQLITE && qb.AllowWriteLimit
+		if qb.CommandType == INSERT || !writeOrder {
+			return fmt.Errorf("%w: %s", ErrOrderNotAllowed, qb.CommandType)
+		}
+	}
+	if len(qb.Group) > 0 && qb.CommandType != SELECT {
+		return fmt.Errorf("%w: %s", ErrGroupNotAllowed, qb.CommandType)
+	}
+	if err := qb.checkSourceValues(); err != nil {
+		return err
+	}
+	// SQL Server declares the alias of an UPDATE or DELETE command in a FROM clause
+	if qb.TableAlias != "" && qb.Dialect == SQLSERVER && (qb.CommandType == UPDATE || qb.CommandType == DELETE) {
+		return fmt.Errorf("%w: table alias on %s", ErrNotSupported, qb.CommandType)
+	}
+	if len(qb.Values) > 0 && qb.CommandType == DELETE {
+		return ErrValuesOnDelete
+	}
+	for _, v := range qb.Values {
+		if v.sequence != "" && qb.Dialect == SQLITE {
+			return fmt.Errorf("%w: sequence of %s", ErrNotSupported, v.column)
+		}
+	}
+	// The rows of a page are arbitrary without an order. SQL Server falls back to ORDER BY (SELECT NULL)
+	// unless the builder is in strict mode.
+	if len(qb.Order) == 0 && qb.pagingMode() == pageFetch && (qb.StrictMode || qb.Dialect != SQLSERVER) {
+		return ErrLimitRequiresOrder
+	}
+	return qb.checkFullText()
 }
 
 func (qb *QueryBuilder) addColumn(name string, length int) int {
@@ -588,27 +1553,75 @@ func (qb *QueryBuilder) addColumn(name string, length int) int {
 	return len(qb.Columns) - 1
 }
 
-func (qb *QueryBuilder) setColumnValue(index int, value interface{}, sqlString bool, defValue interface{}, matchToNull interface{}) *QueryBuilder {
+func (qb *QueryBuilder) setColumnValue(index int, value interface{}, vo ValueCompareOption) *QueryBuilder {
+	qb.touch()
+	qv := queryValue{
+		column:      qb.Columns[index].Name,
+		sqlstring:   vo.SQLString,
+		defvalue:    vo.Default,
+		matchtonull: vo.MatchToNull,
+		defon:       vo.DefaultOn,
+		matchon:     vo.MatchOn,
+		zeronil:     vo.ZeroAsNil,
+		omitzero:    vo.OmitZero,
+		uuid:        vo.DefaultUUID,
+		value:       value,
+	}
 	for i, v := range qb.Values {
-		if !strings.EqualFold(qb.Columns[index].Name, v.column) {
+		if !strings.EqualFold(qv.column, v.column) {
 			continue
 		}
-		qb.Values[i].sqlstring = sqlString
-		qb.Values[i].defvalue = defValue
-		qb.Values[i].matchtonull = matchToNull
-		qb.Values[i].value = value
+		qb.Values[i] = qv
 		return qb
 	}
-	qb.Values = append(qb.Values, queryValue{
-		column:      qb.Columns[index].Name,
-		sqlstring:   sqlString,
-		defvalue:    defValue,
-		matchtonull: matchToNull,
-		value:       value,
-	})
+	qb.Values = append(qb.Values, qv)
 	return qb
 }
 
+// countPlaceholders counts the parameter placeholders of a query outside of string literals.
+// When the placeholders are in sequence, only the placeholder character followed by a number is counted.
+func countPlaceholders(query, pchar string, inSeq bool, enclosing string) int {
+	if pchar == "" {
+		return 0
+	}
+	cnt := 0
+	inStr := false
+	for i := 0; i < len(query); i++ {
+		if enclosing != "" && strings.HasPrefix(query[i:], enclosing) {
+			inStr = !inStr
+			i += len(enclosing) - 1
+			continue
+		}
+		if inStr || !strings.HasPrefix(query[i:], pchar) {
+			continue
+		}
+		j := i + len(pchar)
+		if inSeq {
+			k := j
+			for k < len(query) && query[k] >= '0' && query[k] <= '9' {
+				k++
+			}
+			if k == j {
+				continue
+			}
+			j = k
+		}
+		cnt++
+		i = j - 1
+	}
+	return cnt
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func isNil(value interface{}) bool {
 	if value == nil {
 		return true
@@ -628,6 +1641,14 @@ func isNil(value interface{}) bool {
 	return false
 }
 
+// isZero checks if the value is the zero value of its type
+func isZero(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	return reflect.ValueOf(value).IsZero()
+}
+
 // converts the value to a basic interface as nil or non-nil
 func realValue(value interface{}) interface{} {
 	if isNil(value) {
@@ -651,6 +1672,9 @@ func realValue(value interface{}) interface{} {
 }
 
 func getv(input interface{}) (ret interface{}) {
+	if rv, ok := registeredValue(input); ok {
+		return rv
+	}
 	switch t := input.(type) {
 	case string, int, int8, int16, int32,
 		int64, float32, float64, time.Time, bool,
@@ -682,12 +1706,63 @@ func getv(input interface{}) (ret interface{}) {
 		ret = *t
 	case *ssd.Decimal:
 		ret = *t
+	case json.RawMessage:
+		ret = string(t)
 	case dhl.VarChar, dhl.VarCharMax, dhl.NVarCharMax:
 		ret = t
+	case driver.Valuer:
+		// sql.Null* types return nil when they are not valid
+		if dv, err := t.Value(); err == nil {
+			ret = dv
+		}
+	default:
+		ret = basicValue(reflect.ValueOf(t))
 	}
 	return
 }
 
+// basicTypes are the types that the values of named basic kinds are converted to
+var basicTypes = map[reflect.Kind]reflect.Type{
+	reflect.String:  reflect.TypeOf(""),
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+}
+
+// basicValue converts a value of a named basic kind, such as type Status string, or a pointer to it,
+// to its basic type. Other values return nil.
+func basicValue(v reflect.Value) interface{} {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		return getv(v.Elem().Interface())
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return v.Bytes()
+	}
+	// slices of basic types are arrays, bound on the dialects that have them
+	if v.Kind() == reflect.Slice {
+		if _, ok := basicTypes[v.Type().Elem().Kind()]; ok {
+			return v.Interface()
+		}
+	}
+	if bt, ok := basicTypes[v.Kind()]; ok {
+		return v.Convert(bt).Interface()
+	}
+	return nil
+}
+
 // ParseReserveWordsChars always returns two-element array of opening and closing escape chars
 func ParseReserveWordsChars(ec string) []string {
 	if len(ec) == 1 {
@@ -700,10 +1775,117 @@ func ParseReserveWordsChars(ec string) []string {
 }
 
 // InterpolateTable - interpolate the tables specified with curly braces {} with a schema
+//
+// When a table token is declared with an alias, such as {Orders} o or {Orders} AS o, column references
+// in the form of {Orders}.total anywhere in the query are rewritten to o.total. If a table is declared
+// with more than one alias, its references are interpolated with the schema instead.
+//
+// A token of more than one part, such as {sales.Orders} or {Archive.dbo.Orders}, is not prepended with the schema.
 func InterpolateTable(sql string, schema string) string {
-	if schema != "" {
-		schema = schema + `.`
+	return InterpolateTableFunc(sql, schema, nil)
+}
+
+// InterpolateTableQuoted works like InterpolateTable, but the schema and the table are enclosed with the
+// reserved word escape characters, such as [sales].[Orders] for `[]` or "sales"."Orders" for `"`.
+//
+// The table tokens can contain dots and quoted names, such as {sales.Orders} or {[Order Details]}.
+// The existing quotes are replaced by the escape characters, and a table that has its own schema
+// is not prepended with the schema.
+func InterpolateTableQuoted(sql string, schema string, escapeChar string) string {
+	return InterpolateTableQuotedFunc(sql, schema, escapeChar, nil)
+}
+
+// tableRegexps are the expressions of table tokens, references and alias declarations
+type tableRegexps struct {
+	token *regexp.Regexp
+	ref   *regexp.Regexp
+	alias *regexp.Regexp
+}
+
+func newTableRegexps(name string) tableRegexps {
+	return tableRegexps{
+		token: regexp.MustCompile(`\{(` + name + `)\}`),
+		ref:   regexp.MustCompile(`\{` + name + `\}\.`),
+		alias: regexp.MustCompile(`(?i)\{(` + name + `)\}\s+(?:AS\s+)?([a-zA-Z_][a-zA-Z0-9_]*)`),
+	}
+}
+
+// interpolateTables rewrites the references of aliased tables and replaces the table tokens with the result of the function
+func interpolateTables(sql string, re tableRegexps, table func(name string) string) string {
+	aliases := make(map[string]string)
+	for _, m := range re.alias.FindAllStringSubmatch(sql, -1) {
+		if _, kw := aliasStopWords[strings.ToUpper(m[2])]; kw {
+			continue
+		}
+		if a, ok := aliases[m[1]]; ok && a != m[2] {
+			aliases[m[1]] = ""
+			continue
+		}
+		aliases[m[1]] = m[2]
+	}
+	sql = re.ref.ReplaceAllStringFunc(sql, func(ref string) string {
+		if a := aliases[ref[1:len(ref)-2]]; a != "" {
+			return a + "."
+		}
+		return ref
+	})
+	return re.token.ReplaceAllStringFunc(sql, func(tok string) string {
+		return table(tok[1 : len(tok)-1])
+	})
+}
+
+var (
+	plainTables  = newTableRegexps(`[a-zA-Z0-9\[\]\"\_\-\.]*`)
+	quotedTables = newTableRegexps(`(?:[a-zA-Z0-9\_\-\.]|\[[^\]]*\]|"[^"]*"|` + "`[^`]*`" + `)+`)
+)
+
+// splitParts splits a name on the dots outside of quotes
+func splitParts(name string) []string {
+	var (
+		parts   []string
+		closing byte
+	)
+	start := 0
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case closing != 0:
+			if c == closing {
+				closing = 0
+			}
+		case c == '[':
+			closing = ']'
+		case c == '"' || c == '`':
+			closing = c
+		case c == '.':
+			parts = append(parts, name[start:i])
+			start = i + 1
+		}
 	}
-	re := regexp.MustCompile(`\{([a-zA-Z0-9\[\]\"\_\-]*)\}`)
-	return re.ReplaceAllString(sql, schema+`$1`)
+	return append(parts, name[start:])
+}
+
+// quoteParts encloses every part of a dotted name with the escape characters, replacing the existing quotes
+func quoteParts(name string, ec []string) string {
+	if name == "" {
+		return ""
+	}
+	parts := splitParts(name)
+	for i, p := range parts {
+		if len(p) > 1 && (p[0] == '[' && p[len(p)-1] == ']' ||
+			p[0] == '"' && p[len(p)-1] == '"' ||
+			p[0] == '`' && p[len(p)-1] == '`') {
+			p = p[1 : len(p)-1]
+		}
+		parts[i] = ec[0] + p + ec[1]
+	}
+	return strings.Join(parts, ".")
+}
+
+// aliasStopWords are keywords that may follow a table token but are never aliases
+var aliasStopWords = map[string]struct{}{
+	"WHERE": {}, "SET": {}, "ON": {}, "JOIN": {}, "INNER": {}, "LEFT": {}, "RIGHT": {}, "FULL": {},
+	"CROSS": {}, "OUTER": {}, "ORDER": {}, "GROUP": {}, "HAVING": {}, "LIMIT": {}, "VALUES": {},
+	"UNION": {}, "WITH": {}, "OFFSET": {}, "FETCH": {}, "AND": {}, "OR": {}, "USING": {},
+	"QUALIFY": {}, "RETURNING": {}, "OUTPUT": {}, "WHEN": {}, "MERGE": {},
 }
@@ -1,8 +1,12 @@
 package querybuilder
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -534,3 +538,520 @@ func TestVariablePointerToInterfaceStruct(t *testing.T) {
 	t.Logf("b: %v", realValue(ss.b))
 	t.Logf("ba: %v", realValue(ss.ba))
 }
+
+func TestQualifiedColumn(t *testing.T) {
+	q := New(WithTableName("{Orders} o"), WithSchema("sales"))
+	q.ReservedWordEscapeChar = "[]"
+
+	q.AddColumn(q.Col("o", "status"))
+	q.AddColumn(q.Col("{Orders}", "total"))
+	q.AddFilter(q.Col("o", "customer_key"), 5)
+
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	t.Log(s)
+
+	want := "SELECT [o].[status], o.[total] FROM sales.Orders o WHERE [o].[customer_key] = ?;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
+
+func TestInterpolateTableAliases(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{"SELECT {Orders}.total FROM {Orders} o WHERE {Orders}.status = ?", "SELECT o.total FROM sales.Orders o WHERE o.status = ?"},
+		{"SELECT {Orders}.total FROM {Orders} AS ord", "SELECT ord.total FROM sales.Orders AS ord"},
+		{"SELECT {Orders}.total FROM {Orders}\r\t WHERE {Orders}.status = ?", "SELECT sales.Orders.total FROM sales.Orders\r\t WHERE sales.Orders.status = ?"},
+		{"SELECT {Orders}.total FROM {Orders} a JOIN {Orders} b ON a.id = b.id", "SELECT sales.Orders.total FROM sales.Orders a JOIN sales.Orders b ON a.id = b.id"},
+		{"SELECT {Orders}.total FROM {Orders} QUALIFY ROW_NUMBER() OVER (PARTITION BY {Orders}.cust ORDER BY {Orders}.total) = 1", "SELECT sales.Orders.total FROM sales.Orders QUALIFY ROW_NUMBER() OVER (PARTITION BY sales.Orders.cust ORDER BY sales.Orders.total) = 1"},
+		{"INSERT INTO {Orders} (total) VALUES (?) RETURNING {Orders}.id", "INSERT INTO sales.Orders (total) VALUES (?) RETURNING sales.Orders.id"},
+		{"UPDATE {Orders} OUTPUT INSERTED.id SET total = ?", "UPDATE sales.Orders OUTPUT INSERTED.id SET total = ?"},
+		{"MERGE INTO {Orders} USING src ON {Orders}.id = src.id WHEN MATCHED THEN UPDATE SET total = src.total", "MERGE INTO sales.Orders USING src ON sales.Orders.id = src.id WHEN MATCHED THEN UPDATE SET total = src.total"},
+	}
+	for _, tt := range tests {
+		if got := InterpolateTable(tt.sql, "sales"); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestSourceAs(t *testing.T) {
+	q := New(WithSchema("sales"), WithDialect(POSTGRES))
+	q.SourceAs("{Orders}", "o")
+	q.AddColumn("{Orders}.total").AddColumn("o.status")
+	q.AddFilter("o.customer_key", 5)
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT o.total, o.status FROM sales.Orders o WHERE o.customer_key = $1;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	q = New(WithTableName("{Orders}"), WithTableAlias("o"), WithCommand(INSERT))
+	q.AddValue("total", 10)
+	if s, _, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (total) VALUES (?);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	q = New(WithTableName("{Orders}"), WithTableAlias("o"), WithCommand(DELETE), WithDialect(SQLSERVER))
+	q.AddFilter("o.status", "X")
+	if _, _, err = q.Build(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+}
+
+func TestCaptureArgs(t *testing.T) {
+	q := New(WithTableName("{Users}"), WithCommand(UPDATE))
+
+	captured := make(map[string]interface{})
+	q.CaptureArgs(func(column string, value interface{}) {
+		captured[column] = value
+	})
+	q.AddValue("UserName", "eaglebush")
+	q.AddValue("Birthdate", "GETDATE()", IsSqlString(false))
+	q.AddFilter("UserKey", 5)
+
+	_, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if len(captured) != len(v) {
+		t.Errorf("captured %d values, built %d args", len(captured), len(v))
+	}
+	if captured["UserName"] != "eaglebush" || captured["UserKey"] != 5 {
+		t.Errorf("unexpected captured values: %v", captured)
+	}
+}
+
+func TestAddValuesMap(t *testing.T) {
+	q := New(WithTableName("{Users}"), WithCommand(INSERT))
+	q.AddValuesMap(map[string]interface{}{
+		"UserName": "eaglebush",
+		"Active":   true,
+		"UserKey":  5,
+	})
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "INSERT INTO Users (Active, UserKey, UserName) VALUES (?,?,?);"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{true, 5, "eaglebush"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
+
+func TestAddFiltersMap(t *testing.T) {
+	q := New(WithTableName("{Users}"))
+	q.AddColumn("UserKey")
+	q.AddFiltersMap(map[string]interface{}{
+		"UserName": "eaglebush",
+		"Deleted":  nil,
+		"Active":   true,
+	})
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT UserKey FROM Users WHERE Active = ? AND Deleted IS NULL AND UserName = ?;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{true, "eaglebush"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
+
+func TestSchemaFromContext(t *testing.T) {
+	type schemaKey struct{}
+
+	q := New(WithTableName("{Users}"), WithSchema("dbo"), SchemaFromContext(schemaKey{}))
+	q.AddColumn("UserKey")
+
+	s, _, err := q.BuildContext(context.WithValue(context.Background(), schemaKey{}, "tenant1"))
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserKey FROM tenant1.Users;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	s, _, err = q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserKey FROM dbo.Users;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
+
+func TestTreatZeroAsNil(t *testing.T) {
+	q := New(WithTableName("{Users}"), WithCommand(INSERT))
+	q.AddValue("UserKey", 5)
+	q.AddValue("Age", 0, TreatZeroAsNil())
+	q.AddValue("Birthdate", time.Time{}, TreatZeroAsNil())
+	q.AddValue("Score", 0)
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "INSERT INTO Users (UserKey, Age, Birthdate, Score) VALUES (?,NULL,NULL,?);"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{5, 0}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	q.SkipNilWriteColumn = true
+	s, _, err = q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Users (UserKey, Score) VALUES (?,?);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
+
+func TestArgumentMismatch(t *testing.T) {
+	q := New(WithTableName("{Users}"), WithCommand(UPDATE))
+	q.ParameterChar = "$"
+	q.ParameterInSequence = true
+	q.VerifyPlaceholders = true
+
+	q.AddValue("UserName", nil, Default("unknown"))
+	q.AddFilterExp("Remarks <> '$1'")
+	q.AddFilter("UserKey", 5)
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	t.Log(s, v)
+	if len(v) != 2 {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	q.FilterFunc = func(offset int, char string, inSeq bool) ([]string, []interface{}) {
+		return []string{fmt.Sprintf("Active = %s%d", char, offset+1)}, nil
+	}
+	q.ParameterOffset = 0
+	if _, _, err = q.Build(); !errors.Is(err, ErrArgumentMismatch) {
+		t.Errorf("got %v, want %v", err, ErrArgumentMismatch)
+	}
+}
+
+func TestOmitZero(t *testing.T) {
+	q := New(WithTableName("{Users}"), WithCommand(UPDATE))
+	q.AddValue("UserName", "")
+	q.AddValue("FullName", "", OmitZero())
+	q.AddValue("Age", 0, OmitZero())
+	q.AddFilter("UserKey", 5)
+
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "UPDATE Users SET UserName = ? WHERE UserKey = ?;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	q = New(WithTableName("{Users}"), WithCommand(INSERT), OmitZeroWrite(true))
+	q.AddValue("UserKey", 5)
+	q.AddValue("UserName", "")
+	q.AddValue("Active", false)
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Users (UserKey) VALUES (?);"; s != want || len(v) != 1 {
+		t.Errorf("got %q %v, want %q", s, v, want)
+	}
+
+	q = New(WithTableName("{Users}"), WithCommand(INSERT))
+	q.AddValue("UserKey", 5)
+	q.AddValue("Level", nil, OmitZero(), Default(5))
+	q.AddValue("Rank", nil, OmitZero(), Default(0))
+	s, v, err = q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Users (UserKey, Level) VALUES (?,?);"; s != want || !reflect.DeepEqual(v, []interface{}{5, 5}) {
+		t.Errorf("got %q %v, want %q", s, v, want)
+	}
+}
+
+func TestDefaultAndMatchToNullOn(t *testing.T) {
+	build := func(cmd Command) (string, []interface{}) {
+		q := New(WithTableName("{Users}"), WithCommand(cmd))
+		q.AddValue("Status", nil, DefaultOnInsert("A"))
+		q.AddValue("Remarks", "-", MatchToNullOnUpdate("-"))
+		q.AddValue("Level", nil, DefaultOn(1, INSERT, UPDATE))
+		if cmd == UPDATE {
+			q.AddFilter("UserKey", 5)
+		}
+		s, v, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		return s, v
+	}
+
+	s, v := build(INSERT)
+	if want := "INSERT INTO Users (Status, Remarks, Level) VALUES (?,?,?);"; s != want || !reflect.DeepEqual(v, []interface{}{"A", "-", 1}) {
+		t.Errorf("got %q %v, want %q", s, v, want)
+	}
+	s, v = build(UPDATE)
+	if want := "UPDATE Users SET Status = NULL, Remarks = NULL, Level = ? WHERE UserKey = ?;"; s != want || !reflect.DeepEqual(v, []interface{}{1, 5}) {
+		t.Errorf("got %q %v, want %q", s, v, want)
+	}
+}
+
+func TestRealValueValuer(t *testing.T) {
+	type status string
+	type level int
+	st := status("active")
+	tests := []struct {
+		input interface{}
+		want  interface{}
+	}{
+		{sql.NullString{String: "eaglebush", Valid: true}, "eaglebush"},
+		{sql.NullString{String: "eaglebush"}, nil},
+		{&sql.NullInt64{Int64: 5, Valid: true}, int64(5)},
+		{sql.NullBool{}, nil},
+		{st, "active"},
+		{&st, "active"},
+		{level(3), 3},
+		{uint32(7), uint32(7)},
+	}
+	for _, tt := range tests {
+		if got := realValue(tt.input); got != tt.want {
+			t.Errorf("realValue(%#v) = %#v, want %#v", tt.input, got, tt.want)
+		}
+	}
+
+	q := New(WithTableName("{Users}"), WithCommand(INSERT), SkipNilWrite(true))
+	q.AddValue("UserName", sql.NullString{String: "eaglebush", Valid: true})
+	q.AddValue("FullName", sql.NullString{})
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Users (UserName) VALUES (?);"; s != want || len(v) != 1 || v[0] != "eaglebush" {
+		t.Errorf("got %q %v, want %q", s, v, want)
+	}
+}
+
+func TestNewE(t *testing.T) {
+	q, err := NewE(WithTableName("Users"), WithDialect(POSTGRES))
+	if err != nil || q.ParameterChar != "$" {
+		t.Fatalf("unexpected builder: %v", err)
+	}
+	if _, err := NewE(WithTableName("Users"), WithSchema("")); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+	if _, err := NewE(WithDialect(Dialect(99))); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("expected ErrInvalidOption, got %v", err)
+	}
+	if q := New(WithConfig(nil)); q == nil {
+		t.Error("New must ignore option errors")
+	}
+}
+
+func TestInterpolateTableQuoted(t *testing.T) {
+	tests := []struct {
+		sql    string
+		schema string
+		ec     string
+		want   string
+	}{
+		{"SELECT * FROM {Orders}", "sales", "[]", "SELECT * FROM [sales].[Orders]"},
+		{"SELECT * FROM {[Order Details]}", "sales", `"`, `SELECT * FROM "sales"."Order Details"`},
+		{"SELECT * FROM {hr.Employees}", "sales", "[]", "SELECT * FROM [hr].[Employees]"},
+		{"SELECT {Orders}.total FROM {Orders} o", "", "`", "SELECT o.total FROM `Orders` o"},
+	}
+	for _, tt := range tests {
+		if got := InterpolateTableQuoted(tt.sql, tt.schema, tt.ec); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+
+	q := New(WithTableName("{Users}"), WithDialect(SQLSERVER), WithSchema("dbo"), QuoteTableNames(true))
+	q.AddColumn("UserName")
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM [dbo].[Users];"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
+
+func TestClauseErrors(t *testing.T) {
+	q := New(WithTableName("Users"), WithCommand(DELETE))
+	q.AddValue("UserName", "eaglebush")
+	if _, _, err := q.Build(); !errors.Is(err, ErrValuesOnDelete) {
+		t.Errorf("expected ErrValuesOnDelete, got %v", err)
+	}
+
+	q = New(WithTableName("Users"), WithCommand(UPDATE))
+	q.AddValue("UserName", "eaglebush")
+	q.AddGroup("UserName")
+	if _, _, err := q.Build(); !errors.Is(err, ErrGroupNotAllowed) {
+		t.Errorf("expected ErrGroupNotAllowed, got %v", err)
+	}
+
+	q = New(WithTableName("Users"), WithCommand(DELETE), WithDialect(MYSQL))
+	q.AddOrder("CreatedAt", ASC)
+	q.ResultLimit = "10"
+	if s, _, err := q.Build(); err != nil || s != "DELETE FROM Users ORDER BY CreatedAt ASC LIMIT 10;" {
+		t.Errorf("unexpected MySQL ordered delete: %q %v", s, err)
+	}
+
+	q = New(WithTableName("Users"), WithDialect(ORACLE))
+	q.AddColumn("UserName")
+	q.Paginate(1, 10)
+	if _, _, err := q.Build(); !errors.Is(err, ErrLimitRequiresOrder) {
+		t.Errorf("expected ErrLimitRequiresOrder, got %v", err)
+	}
+}
+
+func TestBuildKeepsValues(t *testing.T) {
+	name := "eaglebush"
+	q := New(WithTableName("Users"), WithCommand(UPDATE))
+	q.AddValue("UserName", &name)
+	q.AddValue("FullName", nil, Default("n/a"))
+	q.AddFilter("UserKey", 5)
+	if _, _, err := q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	name = "eagle"
+	_, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"eagle", "n/a", 5}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+	if q.Values[0].value != &name || q.Values[1].value != nil {
+		t.Errorf("builder values were changed: %v", q.Values)
+	}
+}
+
+func TestFilterFuncOnce(t *testing.T) {
+	q := New(WithTableName("Users"), WithDialect(POSTGRES))
+	q.AddColumn("UserName")
+	q.AddFilter("Active", true)
+	calls := 0
+	q.FilterFunc = func(offset int, char string, inSeq bool) ([]string, []interface{}) {
+		calls++
+		return []string{fmt.Sprintf("UserKey = %s%d", char, offset+1)}, []interface{}{calls}
+	}
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if calls != 1 {
+		t.Errorf("FilterFunc called %d times", calls)
+	}
+	if want := "SELECT UserName FROM Users WHERE Active = $1 AND UserKey = $2;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{true, 1}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
+
+func TestSizeHint(t *testing.T) {
+	q := New(WithTableName("{Users}"), WithCommand(UPDATE), WithDialect(POSTGRES))
+	for i := 0; i < 50; i++ {
+		q.AddValue("Column"+strconv.Itoa(i), i)
+	}
+	q.AddFilter("UserKey", 5)
+	q.AddFilterIn("GroupKey", 1, 2, 3)
+	w := q.resolved(context.Background())
+	s, v, err := w.render(context.Background())
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if n := w.sizeHint(); n < len(s) {
+		t.Errorf("size hint %d is less than the query length %d", n, len(s))
+	}
+	if n := w.argCap(); n < len(v) {
+		t.Errorf("argument capacity %d is less than the arguments %d", n, len(v))
+	}
+}
+
+func TestAddFilterFunc(t *testing.T) {
+	tenant := func(offset int, char string, inSeq bool) ([]string, []interface{}) {
+		return []string{fmt.Sprintf("TenantKey = %s%d", char, offset+1)}, []interface{}{42}
+	}
+	flags := func(offset int, char string, inSeq bool) ([]string, []interface{}) {
+		return []string{fmt.Sprintf("Beta = %s%d", char, offset+1), fmt.Sprintf("Region = %s%d", char, offset+2)}, []interface{}{true, "EU"}
+	}
+	q := New(WithTableName("Users"), WithDialect(POSTGRES))
+	q.AddColumn("UserName")
+	q.AddFilter("Active", true)
+	q.FilterFunc = tenant
+	q.AddFilterFunc(flags)
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT UserName FROM Users WHERE Active = $1 AND TenantKey = $2 AND Beta = $3 AND Region = $4;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{true, 42, true, "EU"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
+
+func BenchmarkBuild(b *testing.B) {
+	q := New(WithTableName("{Users}"), WithCommand(UPDATE), WithDialect(POSTGRES))
+	for i := 0; i < 20; i++ {
+		q.AddValue("Column"+strconv.Itoa(i), i)
+	}
+	q.AddFilter("UserKey", 5)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		q.ParameterOffset = 0
+		if _, _, err := q.Build(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestGroupBeforeOrder(t *testing.T) {
+	q := New(WithTableName("Orders"), WithDialect(POSTGRES))
+	q.AddColumn("CustomerKey").AddColumn("COUNT(*) AS Orders")
+	q.AddFilter("Status", "A")
+	q.AddGroup("CustomerKey")
+	q.AddOrder("CustomerKey", ASC)
+	q.ResultLimitPosition = REAR
+	q.ResultLimit = "10"
+
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT CustomerKey, COUNT(*) AS Orders FROM Orders WHERE Status = $1 GROUP BY CustomerKey ORDER BY CustomerKey ASC LIMIT 10;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
@@ -0,0 +1,103 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RemoveColumn removes a column and its value
+func (qb *QueryBuilder) RemoveColumn(name string) *QueryBuilder {
+	qb.touch()
+	n := len(qb.Columns)
+	columns := qb.Columns[:0]
+	for _, c := range qb.Columns {
+		if !strings.EqualFold(c.Name, name) {
+			columns = append(columns, c)
+		}
+	}
+	qb.Columns = columns
+	values := qb.Values[:0]
+	for _, v := range qb.Values {
+		if !strings.EqualFold(v.column, name) {
+			values = append(values, v)
+		}
+	}
+	qb.Values = values
+	if len(columns) == n {
+		qb.strictFail("RemoveColumn on unknown column " + name)
+	}
+	return qb
+}
+
+// RemoveFilter removes a filter by its index in Filter, or the filters of a column, such as those
+// added by AddFilter, AddFilterIn and Where. Filter expressions and condition trees have no column.
+// An index out of range or an argument of another type is returned as an error by Build.
+func (qb *QueryBuilder) RemoveFilter(indexOrColumn interface{}) *QueryBuilder {
+	qb.touch()
+	switch t := indexOrColumn.(type) {
+	case int:
+		if t < 0 || t >= len(qb.Filter) {
+			if qb.err == nil {
+				qb.err = fmt.Errorf("%w: filter index %d out of range", ErrInvalidOption, t)
+			}
+			return qb
+		}
+		qb.Filter = append(qb.Filter[:t], qb.Filter[t+1:]...)
+	case string:
+		n := len(qb.Filter)
+		filters := qb.Filter[:0]
+		for _, f := range qb.Filter {
+			if !strings.EqualFold(f.column(), t) {
+				filters = append(filters, f)
+			}
+		}
+		qb.Filter = filters
+		if len(filters) == n {
+			qb.strictFail("RemoveFilter on unfiltered column " + t)
+		}
+	default:
+		if qb.err == nil {
+			qb.err = fmt.Errorf("%w: filter %T is neither an index nor a column", ErrInvalidOption, indexOrColumn)
+		}
+	}
+	return qb
+}
+
+// ReplaceFilter replaces the value of the filters of a column, keeping their operators and options.
+// The values of an IN filter are replaced by the value, or by its elements when it is a []interface{}.
+// When the column has no filter, the filter is added with AddFilter.
+func (qb *QueryBuilder) ReplaceFilter(column string, value interface{}) *QueryBuilder {
+	qb.touch()
+	found := false
+	for i, f := range qb.Filter {
+		if !strings.EqualFold(f.column(), column) {
+			continue
+		}
+		found = true
+		if f.in {
+			qb.Filter[i].values = inValues(value)
+			continue
+		}
+		qb.Filter[i].value = value
+	}
+	if !found {
+		qb.AddFilter(column, value)
+	}
+	return qb
+}
+
+// RemoveOrder removes the ORDER BY column
+func (qb *QueryBuilder) RemoveOrder(column string) *QueryBuilder {
+	qb.touch()
+	order := qb.Order[:0]
+	for _, o := range qb.Order {
+		if !strings.EqualFold(o.column, column) {
+			order = append(order, o)
+		}
+	}
+	if len(order) == len(qb.Order) {
+		qb.strictFail("RemoveOrder on unordered column " + column)
+	}
+	qb.Order = order
+	return qb
+}
@@ -0,0 +1,35 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRemove(t *testing.T) {
+	q := New(WithTableName("Users"), WithDialect(POSTGRES))
+	q.AddColumn("UserKey").AddColumn("UserName").AddColumn("Email")
+	q.AddFilter("Status", "A")
+	q.AddFilterExp("Age > 18")
+	q.AddFilterIn("GroupKey", 1, 2)
+	q.Where(Cond{Column: "Score", Operator: OpGte, Value: 10})
+	q.AddOrder("UserName", ASC).AddOrder("UserKey", DESC)
+
+	q.RemoveColumn("email").RemoveFilter(1).RemoveFilter("status").RemoveOrder("UserName")
+	q.ReplaceFilter("GroupKey", []interface{}{3, 4, 5}).ReplaceFilter("Score", 20).ReplaceFilter("Active", true)
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserKey, UserName FROM Users WHERE GroupKey IN ($1, $2, $3) AND Score >= $4 AND Active = $5 ORDER BY UserKey DESC;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{3, 4, 5, 20, true}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	q.RemoveFilter(9)
+	if _, _, err = q.Build(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("got %v, want ErrInvalidOption", err)
+	}
+}
@@ -0,0 +1,47 @@
+package querybuilder
+
+// Reset clears the state of the query, so that the builder can be reused for the next query of a loop
+// without allocating a new one. The table, the command and the settings of the builder, such as the dialect,
+// the placeholders, the scopes and the tenant, are kept, as Spawn keeps them. The ParameterOffset is set to zero.
+func (qb *QueryBuilder) Reset() *QueryBuilder {
+	qb.touch()
+	qb.clearQuery()
+	return qb
+}
+
+// ClearValues removes the columns, their values and the additional rows of an INSERT command
+func (qb *QueryBuilder) ClearValues() *QueryBuilder {
+	qb.touch()
+	qb.Columns, qb.Values, qb.rows = qb.Columns[:0], qb.Values[:0], qb.rows[:0]
+	return qb
+}
+
+// ClearFilters removes the filters and the filter functions. The scopes of the builder are kept.
+func (qb *QueryBuilder) ClearFilters() *QueryBuilder {
+	qb.touch()
+	qb.Filter, qb.FilterFunc, qb.moreFilterFuncs = qb.Filter[:0], nil, qb.moreFilterFuncs[:0]
+	return qb
+}
+
+// ClearOrder removes the ORDER BY columns
+func (qb *QueryBuilder) ClearOrder() *QueryBuilder {
+	qb.touch()
+	qb.Order = qb.Order[:0]
+	return qb
+}
+
+// clearQuery clears the state of the query. The slices keep their capacity.
+func (qb *QueryBuilder) clearQuery() {
+	qb.Columns, qb.Values, qb.rows = qb.Columns[:0], qb.Values[:0], qb.rows[:0]
+	qb.Filter, qb.FilterFunc, qb.moreFilterFuncs = qb.Filter[:0], nil, qb.moreFilterFuncs[:0]
+	qb.Order, qb.Group, qb.buckets = qb.Order[:0], qb.Group[:0], nil
+	qb.UpsertKeys, qb.ReturnColumns = qb.UpsertKeys[:0], qb.ReturnColumns[:0]
+	qb.ResultLimit = ""
+	qb.ParameterOffset = 0
+	qb.original = nil
+	qb.pageOffset, qb.pageSize = 0, 0
+	qb.qualifyExpr, qb.qualifyArgs = "", qb.qualifyArgs[:0]
+	qb.err, qb.strictErr = nil, nil
+	qb.unscoped, qb.unscopedColumns = false, qb.unscopedColumns[:0]
+	qb.ast = nil
+}
@@ -0,0 +1,54 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReset(t *testing.T) {
+	q := New(WithTableName("Users"), WithCommand(UPDATE), WithDialect(POSTGRES), ScopeFilter("Deleted", false))
+	for i, name := range []string{"alice", "bob"} {
+		q.Reset()
+		q.AddValue("UserName", name)
+		q.AddFilter("UserKey", i+1)
+		s, v, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if want := "UPDATE Users SET UserName = $1 WHERE UserKey = $2 AND Deleted = $3;"; s != want {
+			t.Errorf("got %q, want %q", s, want)
+		}
+		if !reflect.DeepEqual(v, []interface{}{name, i + 1, false}) {
+			t.Errorf("unexpected args: %v", v)
+		}
+	}
+}
+
+func TestClear(t *testing.T) {
+	q := New(WithTableName("Users"))
+	q.AddColumn("UserName")
+	q.AddFilter("UserKey", 5)
+	q.AddFilterFunc(func(offset int, char string, inSeq bool) ([]string, []interface{}) {
+		return []string{"Age > " + char}, []interface{}{18}
+	})
+	q.AddOrder("UserName", ASC)
+	q.ClearFilters().ClearOrder()
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if len(v) != 0 {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	q.ClearValues().AddColumn("Email")
+	if s, _, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT Email FROM Users;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
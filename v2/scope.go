@@ -0,0 +1,62 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScopeFilter adds a default filter of the column that SELECT, UPDATE and DELETE commands carry,
+// such as is_active = true. A nil value matches NULL. Builders copied from the builder, with
+// Snapshot or Builder, carry the filter as well. WithoutScope or Unscoped turn it off for a query.
+func ScopeFilter(column string, value interface{}) Option {
+	return func(q *QueryBuilder) error {
+		if column == "" {
+			return fmt.Errorf("%w: empty scope column", ErrInvalidOption)
+		}
+		q.scopes = append(q.scopes[:len(q.scopes):len(q.scopes)], queryFilter{expression: column, value: value})
+		return nil
+	}
+}
+
+// WithoutScope turns off the default filters of the columns for the query
+func (qb *QueryBuilder) WithoutScope(columns ...string) *QueryBuilder {
+	qb.touch()
+	qb.unscopedColumns = append(qb.unscopedColumns[:len(qb.unscopedColumns):len(qb.unscopedColumns)], columns...)
+	return qb
+}
+
+// applyScopes adds the default filters of the builder. The values must be resolved.
+func (qb *QueryBuilder) applyScopes() {
+	if qb.unscoped {
+		return
+	}
+	switch qb.CommandType {
+	case SELECT, UPDATE, DELETE:
+	default:
+		return
+	}
+	for _, f := range qb.scopes {
+		if qb.scopeOff(f.expression) {
+			continue
+		}
+		f.value = realValue(f.value)
+		qb.addScope(f)
+	}
+}
+
+// scopeOff reports whether the default filter of the column is turned off
+func (qb *QueryBuilder) scopeOff(column string) bool {
+	for _, c := range qb.unscopedColumns {
+		if strings.EqualFold(c, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// addScope adds a filter of the builder's scopes. The filters are copied so that the builder
+// is left unchanged, and they do not count as filters for RequireWhere.
+func (qb *QueryBuilder) addScope(f queryFilter) {
+	f.scope = true
+	qb.Filter = append(qb.Filter[:len(qb.Filter):len(qb.Filter)], f)
+}
@@ -0,0 +1,57 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScopeFilter(t *testing.T) {
+	base := New(WithTableName("Users"), WithDialect(POSTGRES), ScopeFilter("is_active", true), ScopeFilter("archived_at", nil))
+	base.AddColumn("UserName")
+	snap := base.Snapshot()
+
+	q := snap.Builder()
+	q.AddFilter("UserKey", 5)
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT UserName FROM Users WHERE UserKey = $1 AND is_active = $2 AND archived_at IS NULL;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{5, true}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+	if len(q.Filter) != 1 {
+		t.Errorf("builder filters were changed: %v", q.Filter)
+	}
+
+	q = snap.Builder()
+	q.WithoutScope("is_active")
+	if s, _, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users WHERE archived_at IS NULL;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	q = snap.Builder()
+	q.Unscoped()
+	if s, _, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	// INSERT commands are not filtered
+	q = New(WithTableName("Users"), WithCommand(INSERT), ScopeFilter("is_active", true))
+	q.AddValue("UserName", "ann")
+	if s, _, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Users (UserName) VALUES (?);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
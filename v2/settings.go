@@ -0,0 +1,135 @@
+package querybuilder
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Settings are the safety and formatting defaults inherited by every QueryBuilder created by New.
+// They are set once at startup with SetDefaultSettings, or from the environment:
+//
+//	QB_STRICT         verifies the placeholders of the final query and turns on strict mode
+//	QB_DENY_RAW       rejects raw SQL values and filter expressions
+//	QB_REQUIRE_WHERE  rejects UPDATE and DELETE commands without filters
+//	QB_COMPACT        renders the query on a single line
+//	QB_LAYOUT         sets the layout of the query: compact, readable or legacy
+//
+// The other environment variables accept the values of strconv.ParseBool.
+type Settings struct {
+	Strict       bool   // Sets VerifyPlaceholders and StrictMode
+	DenyRaw      bool   // Sets DenyRawValues
+	RequireWhere bool   // Sets RequireWhere
+	Compact      bool   // Sets CompactSQL
+	Layout       Layout // Sets Layout
+}
+
+var (
+	settingsMu      sync.RWMutex
+	defaultSettings = settingsFromEnv()
+)
+
+// SetDefaultSettings sets the settings inherited by the query builders created afterwards
+func SetDefaultSettings(s Settings) {
+	settingsMu.Lock()
+	defaultSettings = s
+	settingsMu.Unlock()
+}
+
+// DefaultSettings returns the settings inherited by new query builders
+func DefaultSettings() Settings {
+	settingsMu.RLock()
+	defer settingsMu.RUnlock()
+	return defaultSettings
+}
+
+// WithSettings applies settings to a query builder, overriding the defaults
+func WithSettings(s Settings) Option {
+	return func(q *QueryBuilder) error {
+		q.applySettings(s)
+		return nil
+	}
+}
+
+func (qb *QueryBuilder) applySettings(s Settings) {
+	qb.VerifyPlaceholders = s.Strict
+	qb.StrictMode = s.Strict
+	qb.DenyRawValues = s.DenyRaw
+	qb.RequireWhere = s.RequireWhere
+	qb.CompactSQL = s.Compact
+	qb.Layout = s.Layout
+}
+
+// settingsFromEnv reads the default settings from the environment
+func settingsFromEnv() Settings {
+	return Settings{
+		Strict:       envBool("QB_STRICT"),
+		DenyRaw:      envBool("QB_DENY_RAW"),
+		RequireWhere: envBool("QB_REQUIRE_WHERE"),
+		Compact:      envBool("QB_COMPACT"),
+		Layout:       envLayout("QB_LAYOUT"),
+	}
+}
+
+func envLayout(name string) Layout {
+	switch strings.ToLower(os.Getenv(name)) {
+	case "readable":
+		return LayoutReadable
+	case "legacy":
+		return LayoutLegacy
+	}
+	return LayoutCompact
+}
+
+func envBool(name string) bool {
+	b, _ := strconv.ParseBool(os.Getenv(name))
+	return b
+}
+
+// checkRaw returns an error when raw values or filter expressions are denied
+func (qb *QueryBuilder) checkRaw() error {
+	if !qb.DenyRawValues {
+		return nil
+	}
+	for _, v := range qb.Values {
+		if !v.sqlstring && !isNil(v.value) && !v.expr {
+			return fmt.Errorf("%w: value of %s", ErrRawDenied, v.column)
+		}
+	}
+	for _, f := range qb.Filter {
+		if f.containsvalue {
+			return fmt.Errorf("%w: filter %s", ErrRawDenied, f.expression)
+		}
+	}
+	return nil
+}
+
+// compact replaces the line breaks and tabs outside of string literals with a single space
+func compact(query, enclosing, escape string) string {
+	var sb strings.Builder
+	inStr := false
+	space := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		if n := escaped(query[i:], inStr, enclosing, escape); n > 0 {
+			sb.WriteString(query[i : i+n])
+			i += n - 1
+			continue
+		}
+		if enclosing != "" && strings.HasPrefix(query[i:], enclosing) {
+			inStr = !inStr
+		}
+		if !inStr && (c == ' ' || c == '\r' || c == '\n' || c == '\t') {
+			space = true
+			continue
+		}
+		if space {
+			sb.WriteByte(' ')
+			space = false
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
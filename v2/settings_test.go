@@ -0,0 +1,41 @@
+package querybuilder
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDefaultSettings(t *testing.T) {
+	prev := DefaultSettings()
+	defer SetDefaultSettings(prev)
+	SetDefaultSettings(Settings{DenyRaw: true, RequireWhere: true, Compact: true})
+
+	q := New(WithTableName("{Users}"), WithCommand(DELETE))
+	if _, _, err := q.Build(); !errors.Is(err, ErrNoFilter) {
+		t.Errorf("expected ErrNoFilter, got %v", err)
+	}
+
+	q = New(WithTableName("{Users}"), WithCommand(UPDATE))
+	q.AddValue("Birthdate", "GETDATE()", IsSqlString(false))
+	q.AddFilter("UserKey", 5)
+	if _, _, err := q.Build(); !errors.Is(err, ErrRawDenied) {
+		t.Errorf("expected ErrRawDenied, got %v", err)
+	}
+
+	q = New(WithTableName("{Users}"))
+	q.AddColumn("UserName")
+	q.AddFilter("UserKey", 5)
+	q.AddFilter("Status", "A")
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users WHERE UserKey = ? AND Status = ?;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	q = New(WithTableName("{Users}"), WithCommand(DELETE), WithSettings(Settings{}))
+	if _, _, err := q.Build(); err != nil {
+		t.Errorf("unexpected error with overridden settings: %s", err)
+	}
+}
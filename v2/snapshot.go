@@ -0,0 +1,70 @@
+package querybuilder
+
+import (
+	"context"
+)
+
+// Snapshot is an immutable copy of a query builder. Unlike a QueryBuilder, which advances its
+// ParameterOffset on every build, a snapshot can be built from many goroutines at once, so that
+// a prototype builder can be configured once and shared.
+//
+// Values given as pointers are read when the snapshot is built, and must not be changed meanwhile.
+type Snapshot struct {
+	qb *QueryBuilder
+}
+
+// Snapshot returns an immutable copy of the builder. Later changes to the builder
+// do not affect the snapshot.
+func (qb *QueryBuilder) Snapshot() *Snapshot {
+	c := *qb
+	c.memo = nil
+	c.Columns = append([]QueryColumn(nil), qb.Columns...)
+	c.Values = append([]queryValue(nil), qb.Values...)
+	c.Order = append([]querySort(nil), qb.Order...)
+	c.Group = append([]string(nil), qb.Group...)
+	c.UpsertKeys = append([]string(nil), qb.UpsertKeys...)
+	c.ReturnColumns = append([]string(nil), qb.ReturnColumns...)
+	c.qualifyArgs = append([]interface{}(nil), qb.qualifyArgs...)
+	c.moreFilterFuncs = append([]FilterFunc(nil), qb.moreFilterFuncs...)
+	c.scopes = append([]queryFilter(nil), qb.scopes...)
+	c.unscopedColumns = append([]string(nil), qb.unscopedColumns...)
+	c.Filter = make([]queryFilter, len(qb.Filter))
+	for i, f := range qb.Filter {
+		f.values = append([]interface{}(nil), f.values...)
+		c.Filter[i] = f
+	}
+	c.sourceColumns = append([]string(nil), qb.sourceColumns...)
+	c.sourceRows = make([][]interface{}, len(qb.sourceRows))
+	for i, r := range qb.sourceRows {
+		c.sourceRows[i] = append([]interface{}(nil), r...)
+	}
+	c.rows = make([][]interface{}, len(qb.rows))
+	for i, r := range qb.rows {
+		c.rows[i] = append([]interface{}(nil), r...)
+	}
+	if qb.original != nil {
+		c.original = make(map[string]interface{}, len(qb.original))
+		for k, v := range qb.original {
+			c.original[k] = v
+		}
+	}
+	return &Snapshot{qb: &c}
+}
+
+// Build builds the query of the snapshot. The parameter sequence always starts at
+// the ParameterOffset of the builder when the snapshot was taken.
+func (s *Snapshot) Build() (query string, args []interface{}, err error) {
+	return s.BuildContext(context.Background())
+}
+
+// BuildContext builds the query of the snapshot with the request-scoped settings of the context
+func (s *Snapshot) BuildContext(ctx context.Context) (query string, args []interface{}, err error) {
+	// each build works on its own copy, so that the offset of the snapshot stays unchanged
+	w := *s.qb
+	return w.build(ctx)
+}
+
+// Builder returns a new query builder from the snapshot, to be changed without affecting the snapshot
+func (s *Snapshot) Builder() *QueryBuilder {
+	return s.qb.Snapshot().qb
+}
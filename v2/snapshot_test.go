@@ -0,0 +1,46 @@
+package querybuilder
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	q := New(WithTableName("Users"), WithDialect(POSTGRES))
+	q.AddColumn("UserName")
+	q.AddFilter("UserKey", 5)
+	q.AddFilterIn("GroupKey", 1, 2)
+	snap := q.Snapshot()
+
+	// changes to the builder do not reach the snapshot
+	q.AddFilter("Active", true)
+	q.Filter[1].values[0] = 9
+
+	want := "SELECT UserName FROM Users WHERE UserKey = $1 AND GroupKey IN ($2, $3);"
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, v, err := snap.Build()
+			if err != nil {
+				t.Errorf("Error: %s", err)
+				return
+			}
+			if s != want {
+				t.Errorf("got %q, want %q", s, want)
+			}
+			if !reflect.DeepEqual(v, []interface{}{5, 1, 2}) {
+				t.Errorf("unexpected args: %v", v)
+			}
+		}()
+	}
+	wg.Wait()
+
+	b := snap.Builder()
+	b.AddFilter("Active", true)
+	if s, _, _ := snap.Build(); s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
@@ -0,0 +1,35 @@
+package querybuilder
+
+// SoftDelete sets the column that marks the deleted rows. DELETE commands set the column to the current time
+// of the database instead of deleting the rows, and SELECT, UPDATE and DELETE commands skip the deleted rows.
+// Unscoped turns it off for a query.
+func SoftDelete(column string) Option {
+	return func(q *QueryBuilder) error {
+		q.softDelete = column
+		return nil
+	}
+}
+
+// Unscoped sets the query to see and delete all the rows, ignoring SoftDelete and the filters of ScopeFilter
+func (qb *QueryBuilder) Unscoped() *QueryBuilder {
+	qb.touch()
+	qb.unscoped = true
+	return qb
+}
+
+// applySoftDelete turns a DELETE command into an UPDATE command of the deleted column,
+// and skips the deleted rows. The values must be resolved.
+func (qb *QueryBuilder) applySoftDelete() {
+	if qb.softDelete == "" || qb.unscoped {
+		return
+	}
+	switch qb.CommandType {
+	case DELETE:
+		qb.CommandType = UPDATE
+		qb.addGenerated(queryValue{column: qb.softDelete, value: qb.currentTimestamp(), expr: true})
+	case SELECT, UPDATE:
+	default:
+		return
+	}
+	qb.addScope(queryFilter{expression: qb.softDelete})
+}
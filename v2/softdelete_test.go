@@ -0,0 +1,49 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSoftDelete(t *testing.T) {
+	q := New(WithTableName("Orders"), WithCommand(DELETE), WithDialect(POSTGRES), SoftDelete("deleted_at"))
+	q.AddFilter("OrderKey", 5)
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "UPDATE Orders SET deleted_at = NOW() WHERE OrderKey = $1 AND deleted_at IS NULL;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{5}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+	if q.CommandType != DELETE || len(q.Values) != 0 || len(q.Filter) != 1 {
+		t.Errorf("builder was changed: %v %v", q.CommandType, q.Filter)
+	}
+
+	q = New(WithTableName("Orders"), WithDialect(SQLSERVER), SoftDelete("deleted_at"))
+	q.AddColumn("OrderKey")
+	if s, _, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT OrderKey FROM Orders WHERE deleted_at IS NULL;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	q.Unscoped()
+	if s, _, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT OrderKey FROM Orders;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	// the scope is not a filter of the caller
+	q = New(WithTableName("Orders"), WithCommand(DELETE), SoftDelete("deleted_at"))
+	q.RequireWhere = true
+	if _, _, err = q.Build(); !errors.Is(err, ErrNoFilter) {
+		t.Errorf("expected ErrNoFilter, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package querybuilder
+
+// SpawnOption selects the state of a builder that Spawn copies
+type SpawnOption func(dst, src *QueryBuilder)
+
+// Spawn returns a new builder of the same table and command with the settings of the builder, such as the dialect,
+// the placeholders, the schema, the flags, the scopes, the tenant and the timestamps. The state of the query,
+// such as the columns, the filters, the order and the pagination, is not copied unless it is selected by the options:
+//
+//	base := New(WithTableName("Orders"), WithDialect(POSTGRES))
+//	base.AddFilter("Status", "A").AddOrder("Created", DESC)
+//	recent := base.Spawn(CopyFilters(), CopyOrder())
+//
+// The spawned builder starts with a zero ParameterOffset. Later changes to either builder do not affect the other.
+func (qb *QueryBuilder) Spawn(options ...SpawnOption) *QueryBuilder {
+	src := qb.Snapshot().qb
+	c := *src
+	c.clearQuery()
+	for _, o := range options {
+		if o != nil {
+			o(&c, src)
+		}
+	}
+	return &c
+}
+
+// CopyColumns copies the columns, their values and the GROUP BY columns to a spawned builder
+func CopyColumns() SpawnOption {
+	return func(dst, src *QueryBuilder) {
+		dst.Columns, dst.Values, dst.Group = src.Columns, src.Values, src.Group
+	}
+}
+
+// CopyFilters copies the filters and the filter functions to a spawned builder
+func CopyFilters() SpawnOption {
+	return func(dst, src *QueryBuilder) {
+		dst.Filter, dst.FilterFunc, dst.moreFilterFuncs = src.Filter, src.FilterFunc, src.moreFilterFuncs
+	}
+}
+
+// CopyOrder copies the ORDER BY columns to a spawned builder
+func CopyOrder() SpawnOption {
+	return func(dst, src *QueryBuilder) {
+		dst.Order = src.Order
+	}
+}
@@ -0,0 +1,42 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSpawn(t *testing.T) {
+	base := New(WithTableName("Orders"), WithDialect(POSTGRES), ScopeFilter("Deleted", false))
+	base.AddColumn("OrderKey")
+	base.AddFilter("Status", "A")
+	base.AddOrder("Created", DESC)
+	base.ResultLimit = "10"
+	if _, _, err := base.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+
+	q := base.Spawn(CopyFilters(), CopyOrder())
+	q.AddColumn("Total")
+	q.AddFilter("CustomerKey", 7)
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT Total FROM Orders WHERE Status = $1 AND CustomerKey = $2 AND Deleted = $3 ORDER BY Created DESC;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"A", 7, false}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+	if len(base.Filter) != 1 || len(base.Columns) != 1 {
+		t.Errorf("base builder was changed: %v %v", base.Filter, base.Columns)
+	}
+
+	q = base.Spawn(CopyColumns())
+	if s, _, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT OrderKey FROM Orders WHERE Deleted = $1;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
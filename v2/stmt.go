@@ -0,0 +1,77 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Stmt is a query compiled by Compile. The SQL is rendered once, and the arguments of each
+// execution are produced without rendering it again.
+type Stmt struct {
+	query   string
+	columns []string      // column or filter expression of each argument
+	args    []interface{} // arguments of the compiled builder
+}
+
+// Compile builds the query once and returns a statement that produces the arguments of later executions,
+// such as in loops that insert many rows of the same shape. The SQL is frozen with the values at compile time:
+// a value that was NULL, skipped or raw stays so.
+func (qb *QueryBuilder) Compile() (*Stmt, error) {
+	st := &Stmt{}
+	capture := qb.captureArgs
+	qb.captureArgs = func(column string, value interface{}) {
+		st.columns = append(st.columns, column)
+		if capture != nil {
+			capture(column, value)
+		}
+	}
+	query, args, err := qb.build(context.Background())
+	qb.captureArgs = capture
+	if err != nil {
+		return nil, err
+	}
+	st.query, st.args = query, args
+	return st, nil
+}
+
+// SQL returns the compiled query
+func (st *Stmt) SQL() string {
+	return st.query
+}
+
+// Args returns the arguments for the values, given in the order of the placeholders of the query
+func (st *Stmt) Args(values ...interface{}) ([]interface{}, error) {
+	if len(values) != len(st.args) {
+		return nil, fmt.Errorf("%w: %d placeholders, %d arguments", ErrArgumentMismatch, len(st.args), len(values))
+	}
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = realValue(v)
+	}
+	return args, nil
+}
+
+// ArgsFromStruct returns the arguments from the fields of a struct, mapped to the columns and filters
+// the same way as FromStruct. Arguments without a matching field keep their compiled values.
+func (st *Stmt) ArgsFromStruct(v interface{}) ([]interface{}, error) {
+	rv, ok := structValue(v)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not a struct", ErrNotSupported, v)
+	}
+	fields := structFields(rv.Type())
+	args := make([]interface{}, len(st.args))
+	copy(args, st.args)
+	for i, c := range st.columns {
+		if c == "" {
+			continue
+		}
+		for _, f := range fields {
+			if strings.EqualFold(f.column, c) {
+				args[i] = realValue(rv.FieldByIndex(f.index).Interface())
+				break
+			}
+		}
+	}
+	return args, nil
+}
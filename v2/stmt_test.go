@@ -0,0 +1,35 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	type user struct {
+		UserKey  int `qb:"UserKey,key"`
+		UserName string
+	}
+	q := New(WithTableName("Users"), WithDialect(POSTGRES))
+	q.UpdateStruct(user{UserKey: 1, UserName: "a"})
+	st, err := q.Compile()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "UPDATE Users SET UserName = $1 WHERE UserKey = $2;"; st.SQL() != want {
+		t.Errorf("got %q, want %q", st.SQL(), want)
+	}
+
+	args, err := st.ArgsFromStruct(&user{UserKey: 2, UserName: "b"})
+	if err != nil || !reflect.DeepEqual(args, []interface{}{"b", 2}) {
+		t.Errorf("unexpected args: %v %v", args, err)
+	}
+	args, err = st.Args("c", 3)
+	if err != nil || !reflect.DeepEqual(args, []interface{}{"c", 3}) {
+		t.Errorf("unexpected args: %v %v", args, err)
+	}
+	if _, err := st.Args("c"); !errors.Is(err, ErrArgumentMismatch) {
+		t.Errorf("expected ErrArgumentMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// BuildTo builds the query like Build, memoized when MemoizeBuild is set, and writes it to w, returning the arguments.
+// It suits tooling that exports queries to files or scripts. The query is written at once; Batch.BuildTo writes
+// the statements of a batch as each one is built.
+func (qb *QueryBuilder) BuildTo(w io.Writer) ([]interface{}, error) {
+	query, args, err := qb.Build()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(w, query); err != nil {
+		return nil, err
+	}
+	return args, nil
+}
+
+// BuildTo writes the statements of the batch to w as each one is built, so that large batches
+// are not held in memory as a single query. The parameter sequence continues as in Build.
+// When a statement fails, the statements before it have already been written.
+func (b *Batch) BuildTo(w io.Writer) ([]interface{}, error) {
+	return b.buildTo(context.Background(), w)
+}
+
+func (b *Batch) buildTo(ctx context.Context, w io.Writer) (args []interface{}, err error) {
+	offset := 0
+	for i, qb := range b.Builders {
+		// Oracle drivers reject the statement terminator, so the statements cannot be separated
+		if qb.Dialect == ORACLE {
+			return nil, fmt.Errorf("%w: combined batch", ErrNotSupported)
+		}
+		q, a, next, err := qb.buildAt(ctx, offset)
+		if err != nil {
+			return nil, fmt.Errorf("batch statement %d: %w", i+1, err)
+		}
+		if i > 0 {
+			q = qb.separator() + q
+		}
+		if _, err := io.WriteString(w, q); err != nil {
+			return nil, err
+		}
+		args = append(args, a...)
+		offset = next
+	}
+	return args, nil
+}
@@ -0,0 +1,56 @@
+package querybuilder
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestBuildTo(t *testing.T) {
+	ins := New(WithTableName("Users"), WithCommand(INSERT), WithDialect(POSTGRES))
+	ins.AddValue("UserName", "a")
+	del := New(WithTableName("Users"), WithCommand(DELETE), WithDialect(POSTGRES))
+	del.AddFilter("UserKey", 5)
+
+	var buf bytes.Buffer
+	args, err := ins.BuildTo(&buf)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Users (UserName) VALUES ($1);"; buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"a"}) {
+		t.Errorf("unexpected args: %v", args)
+	}
+
+	// a memoized builder is rendered once
+	memo := New(WithTableName("Users"), WithDialect(POSTGRES), WithMemoize(true))
+	memo.AddColumn("UserName")
+	memo.AddFilter("UserKey", 5)
+	buf.Reset()
+	if _, err = memo.BuildTo(&buf); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	rendered := memo.memo
+	memo.ParameterOffset = 0
+	buf.Reset()
+	if _, err = memo.BuildTo(&buf); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if memo.memo != rendered || buf.String() != "SELECT UserName FROM Users WHERE UserKey = $1;" {
+		t.Errorf("got %q, query rendered again: %t", buf.String(), memo.memo != rendered)
+	}
+
+	buf.Reset()
+	if args, err = NewBatch(ins, del).BuildTo(&buf); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	query, _, _ := NewBatch(ins, del).Build()
+	if buf.String() != query {
+		t.Errorf("got %q, want %q", buf.String(), query)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"a", 5}) {
+		t.Errorf("unexpected args: %v", args)
+	}
+}
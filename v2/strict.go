@@ -0,0 +1,29 @@
+package querybuilder
+
+import "fmt"
+
+// Strict turns the silent accommodations of the builder into errors returned by Build:
+// AddColumn and SetColumnValue on DELETE commands, SetColumnValue on unknown columns,
+// raw values that cannot be rendered, and pages without ORDER BY on SQL Server.
+func Strict() Option {
+	return func(q *QueryBuilder) error {
+		q.StrictMode = true
+		return nil
+	}
+}
+
+// strictFail records the first silent accommodation when the builder is in strict mode
+func (qb *QueryBuilder) strictFail(msg string) {
+	qb.touch()
+	if qb.StrictMode && qb.strictErr == nil {
+		qb.strictErr = fmt.Errorf("%w: %s", ErrStrict, msg)
+	}
+}
+
+// strictCheck returns the first silent accommodation recorded in strict mode
+func (qb *QueryBuilder) strictCheck() error {
+	if !qb.StrictMode {
+		return nil
+	}
+	return qb.strictErr
+}
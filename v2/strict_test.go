@@ -0,0 +1,44 @@
+package querybuilder
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	q := New(WithTableName("Users"), WithCommand(DELETE), Strict())
+	q.AddColumn("UserName")
+	q.AddFilter("UserKey", 5)
+	if _, _, err := q.Build(); !errors.Is(err, ErrStrict) {
+		t.Errorf("expected ErrStrict for AddColumn on DELETE, got %v", err)
+	}
+
+	q = New(WithTableName("Users"), WithCommand(UPDATE), Strict())
+	q.AddValue("UserName", "eaglebush")
+	q.SetColumnValue("username", "eagle")
+	q.AddFilter("UserKey", 5)
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if s != "UPDATE Users SET UserName = ? WHERE UserKey = ?;" || v[0] != "eagle" {
+		t.Errorf("unexpected query %q %v", s, v)
+	}
+	q.SetColumnValue("FullName", "Eagle Bush")
+	if _, _, err := q.Build(); !errors.Is(err, ErrStrict) {
+		t.Errorf("expected ErrStrict for an unknown column, got %v", err)
+	}
+
+	q = New(WithTableName("Users"), WithCommand(UPDATE), Strict())
+	q.AddValue("UserName", "eaglebush")
+	q.AddOrder("UserName", ASC)
+	if _, _, err := q.Build(); !errors.Is(err, ErrOrderNotAllowed) {
+		t.Errorf("expected ErrOrderNotAllowed for ORDER BY on UPDATE, got %v", err)
+	}
+
+	q = New(WithTableName("Users"), WithCommand(INSERT), Strict())
+	q.AddValue("CreatedAt", 5, IsSqlString(false))
+	if _, _, err := q.Build(); !errors.Is(err, ErrStrict) {
+		t.Errorf("expected ErrStrict for a raw non-string value, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// String renders the query of the builder for logs. The values are left as ? placeholders and the
+// string literals of raw values and filter expressions are redacted as '?', so that a builder can
+// be logged without leaking its data. The builder is left unchanged. A builder that cannot be built
+// is rendered as its command and table with the error.
+func (qb *QueryBuilder) String() string {
+	w := *qb
+	w.memo = nil
+	w.MemoizeBuild = false
+	w.CacheQueries = false
+	w.CompactSQL = true
+	w.ParameterChar = "?"
+	w.ParameterInSequence = false
+	w.ParameterOffset = 0
+	w.Warnings = WarnSilent
+	w.metrics = nil
+	w.commentFunc = nil
+	w.tags, w.tagsFunc = nil, nil
+	query, _, err := w.build(context.Background())
+	if err != nil {
+		return fmt.Sprintf("%s %s: %s", qb.CommandType, qb.TableName, err)
+	}
+	return redactLiterals(query, qb.StringEnclosingChar, qb.StringEscapeChar)
+}
+
+// redactLiterals replaces the content of the string literals of a query with ?
+func redactLiterals(query, enclosing, escape string) string {
+	if enclosing == "" {
+		return query
+	}
+	var sb strings.Builder
+	inStr := false
+	for i := 0; i < len(query); i++ {
+		// an escaped or doubled enclosing character does not end the literal
+		if inStr && escape != "" && escape != enclosing && strings.HasPrefix(query[i:], escape) {
+			i += len(escape)
+			continue
+		}
+		if inStr && strings.HasPrefix(query[i:], enclosing+enclosing) {
+			i += 2*len(enclosing) - 1
+			continue
+		}
+		if strings.HasPrefix(query[i:], enclosing) {
+			inStr = !inStr
+			if inStr {
+				sb.WriteString(enclosing + "?")
+			} else {
+				sb.WriteString(enclosing)
+			}
+			i += len(enclosing) - 1
+			continue
+		}
+		if !inStr {
+			sb.WriteByte(query[i])
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,27 @@
+package querybuilder
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	q := New(WithTableName("Users"), WithCommand(UPDATE), WithDialect(POSTGRES))
+	q.AddValue("Email", "jdoe@example.com")
+	q.AddValue("Notes", "CONCAT(Notes, 'O''Brien', 'x\\'y')", IsSqlString(false))
+	q.AddFilter("UserKey", 5)
+	q.AddFilterIn("Status", "A", "B")
+	q.AddFilterExp("SSN = '123-45-6789'")
+	want := "UPDATE Users SET Email = ?, Notes = CONCAT(Notes, '?', '?') WHERE UserKey = ? AND Status IN (?, ?) AND SSN = '?';"
+	if s := fmt.Sprint(q); s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if q.ParameterOffset != 0 {
+		t.Errorf("unexpected parameter offset %d", q.ParameterOffset)
+	}
+
+	q = New(WithTableName("Users"))
+	if s := q.String(); s != "SELECT Users: no columns were specified" {
+		t.Errorf("unexpected string: %q", s)
+	}
+}
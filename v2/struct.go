@@ -0,0 +1,216 @@
+package querybuilder
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// structField is a struct field mapped to a column
+type structField struct {
+	index  []int  // index sequence of the field for reflect.Value.FieldByIndex
+	name   string // Go name of the field
+	column string // column name from the qb or db tag, or the field name
+	bind   string // bind name of named queries from the db tag, or the lower case field name
+	key    bool   // field is tagged as a key column
+}
+
+// cached field maps per struct type
+var structFieldCache sync.Map
+
+// FromStruct adds a value for each exported field of a struct or a pointer to a struct.
+//
+// The column name is taken from the `qb` tag, then the `db` tag, then the field name.
+// Fields tagged with "-" are ignored. Nil pointer fields are added as nil values, so they
+// follow the SkipNilWriteColumn setting. The value options are applied to every field.
+func (qb *QueryBuilder) FromStruct(v interface{}, vcOpts ...ValueOption) *QueryBuilder {
+	rv, ok := structValue(v)
+	if !ok {
+		return qb
+	}
+	for _, f := range structFields(rv.Type()) {
+		qb.AddValue(f.column, rv.FieldByIndex(f.index).Interface(), vcOpts...)
+	}
+	return qb
+}
+
+// UpdateStruct sets the command to UPDATE, adds a value for each field of a struct
+// and adds a filter for each field tagged as a key, such as `qb:"user_key,key"`.
+//
+// The value options are applied to the non-key fields.
+func (qb *QueryBuilder) UpdateStruct(v interface{}, vcOpts ...ValueOption) *QueryBuilder {
+	rv, ok := structValue(v)
+	if !ok {
+		return qb
+	}
+	return qb.updateFields(rv, nil, vcOpts...)
+}
+
+// UpdateWithMask works like UpdateStruct, but only the fields named in the mask are set.
+// The mask can contain column names or field names, matched regardless of case. Fields tagged
+// as keys are always added as filters. Fields outside the mask are ignored regardless of their value.
+func (qb *QueryBuilder) UpdateWithMask(v interface{}, fields []string, vcOpts ...ValueOption) *QueryBuilder {
+	rv, ok := structValue(v)
+	if !ok {
+		return qb
+	}
+	mask := make(map[string]struct{}, len(fields))
+	for _, f := range fields {
+		mask[strings.ToLower(f)] = struct{}{}
+	}
+	return qb.updateFields(rv, mask, vcOpts...)
+}
+
+// updateFields sets the command to UPDATE and adds the values and key filters of a struct.
+// When the mask is not nil, only the fields in the mask are set.
+func (qb *QueryBuilder) updateFields(rv reflect.Value, mask map[string]struct{}, vcOpts ...ValueOption) *QueryBuilder {
+	qb.touch()
+	qb.CommandType = UPDATE
+	for _, f := range structFields(rv.Type()) {
+		fv := rv.FieldByIndex(f.index).Interface()
+		if f.key {
+			qb.AddFilter(f.column, fv)
+			continue
+		}
+		if mask != nil {
+			_, inCol := mask[strings.ToLower(f.column)]
+			_, inName := mask[strings.ToLower(f.name)]
+			if !inCol && !inName {
+				continue
+			}
+		}
+		qb.AddValue(f.column, fv, vcOpts...)
+	}
+	return qb
+}
+
+// ColumnsFromStruct adds a column for each field of a struct type, so that the selected columns
+// match the struct being scanned into. The value can be a struct, a pointer to a struct or a nil pointer of a struct type.
+func (qb *QueryBuilder) ColumnsFromStruct(v interface{}) *QueryBuilder {
+	return qb.columnsFromType(reflect.TypeOf(v))
+}
+
+// SelectStruct sets the command to SELECT and adds a column for each field of T
+func SelectStruct[T any](qb *QueryBuilder) *QueryBuilder {
+	qb.CommandType = SELECT
+	return qb.columnsFromType(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+func (qb *QueryBuilder) columnsFromType(t reflect.Type) *QueryBuilder {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return qb
+	}
+	for _, f := range structFields(t) {
+		qb.AddColumn(f.column)
+	}
+	return qb
+}
+
+// Original records a snapshot of the original values of a record from a struct or a map[string]interface{}.
+// When set, UPDATE commands only set the columns whose values differ from the snapshot.
+// Build returns ErrNoChanges when no column was changed.
+func (qb *QueryBuilder) Original(v interface{}) *QueryBuilder {
+	qb.touch()
+	qb.original = make(map[string]interface{})
+	if m, ok := v.(map[string]interface{}); ok {
+		for k, mv := range m {
+			qb.original[strings.ToLower(k)] = realValue(mv)
+		}
+		return qb
+	}
+	rv, ok := structValue(v)
+	if !ok {
+		return qb
+	}
+	for _, f := range structFields(rv.Type()) {
+		qb.original[strings.ToLower(f.column)] = realValue(rv.FieldByIndex(f.index).Interface())
+	}
+	return qb
+}
+
+// changed checks if a column value differs from the original snapshot.
+// Columns that are not in the snapshot are always changed.
+func (qb *QueryBuilder) changed(column string, value interface{}) bool {
+	orig, ok := qb.original[strings.ToLower(column)]
+	if !ok {
+		return true
+	}
+	return !reflect.DeepEqual(orig, value)
+}
+
+// structValue dereferences v until a struct is reached
+func structValue(v interface{}) (reflect.Value, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return rv, false
+		}
+		rv = rv.Elem()
+	}
+	return rv, rv.Kind() == reflect.Struct
+}
+
+// structFields returns the column-mapped fields of a struct type
+func structFields(t reflect.Type) []structField {
+	if f, ok := structFieldCache.Load(t); ok {
+		return f.([]structField)
+	}
+	fields := collectFields(t, nil)
+	structFieldCache.Store(t, fields)
+	return fields
+}
+
+func collectFields(t reflect.Type, index []int) []structField {
+	fields := make([]structField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, hasTag := sf.Tag.Lookup("qb")
+		if !hasTag {
+			tag, hasTag = sf.Tag.Lookup("db")
+		}
+		if tag == "-" {
+			continue
+		}
+		idx := make([]int, len(index)+1)
+		copy(idx, index)
+		idx[len(index)] = i
+		if sf.Anonymous && !hasTag && sf.Type.Kind() == reflect.Struct {
+			fields = append(fields, collectFields(sf.Type, idx)...)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = sf.Name
+		}
+		bind, _, _ := strings.Cut(sf.Tag.Get("db"), ",")
+		if bind == "" {
+			bind = strings.ToLower(sf.Name)
+		}
+		fields = append(fields, structField{
+			index:  idx,
+			name:   sf.Name,
+			column: name,
+			bind:   bind,
+			key:    hasTagOption(opts, "key"),
+		})
+	}
+	return fields
+}
+
+// hasTagOption checks if a comma-separated tag option list contains an option
+func hasTagOption(opts, option string) bool {
+	for opts != "" {
+		var o string
+		o, opts, _ = strings.Cut(opts, ",")
+		if strings.TrimSpace(o) == option {
+			return true
+		}
+	}
+	return false
+}
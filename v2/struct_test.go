@@ -0,0 +1,150 @@
+package querybuilder
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type auditFields struct {
+	CreatedBy string `qb:"created_by"`
+}
+
+type userRecord struct {
+	auditFields
+	UserKey  int        `qb:"user_key"`
+	UserName string     `db:"user_name"`
+	FullName *string    `qb:"full_name"`
+	Birthday *time.Time `qb:"birthday"`
+	Ignored  string     `qb:"-"`
+	Active   bool
+	secret   string
+}
+
+func TestFromStruct(t *testing.T) {
+	fn := "Elizalde Baguinon"
+	u := userRecord{
+		auditFields: auditFields{CreatedBy: "admin"},
+		UserKey:     5,
+		UserName:    "eaglebush",
+		FullName:    &fn,
+		Ignored:     "x",
+		Active:      true,
+		secret:      "y",
+	}
+
+	q := New(WithTableName("{Users}"), WithCommand(INSERT), SkipNilWrite(true))
+	q.FromStruct(&u)
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	t.Log(s, v)
+
+	want := "INSERT INTO Users (created_by, user_key, user_name, full_name, Active) VALUES (?,?,?,?,?);"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if len(v) != 5 {
+		t.Errorf("got %d args, want 5", len(v))
+	}
+}
+
+func TestUpdateStruct(t *testing.T) {
+	type user struct {
+		UserKey  int    `qb:"user_key,key"`
+		UserName string `qb:"user_name"`
+		Email    string `qb:"email"`
+	}
+
+	q := New(WithTableName("{Users}"))
+	q.UpdateStruct(user{UserKey: 5, UserName: "eaglebush", Email: "eaglebush@example.com"})
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	t.Log(s, v)
+
+	want := "UPDATE Users SET user_name = ?, email = ? WHERE user_key = ?;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if len(v) != 3 || v[2] != 5 {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
+
+func TestSelectStruct(t *testing.T) {
+	q := New(WithTableName("{Users}"))
+	SelectStruct[userRecord](q)
+	q.AddFilter("user_key", 5)
+
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT created_by, user_key, user_name, full_name, birthday, Active FROM Users WHERE user_key = ?;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	q2 := New(WithTableName("{Users}")).ColumnsFromStruct((*userRecord)(nil))
+	if len(q2.Columns) != len(q.Columns) {
+		t.Errorf("got %d columns, want %d", len(q2.Columns), len(q.Columns))
+	}
+}
+
+func TestUpdateWithMask(t *testing.T) {
+	type user struct {
+		UserKey  int     `qb:"user_key,key"`
+		UserName string  `qb:"user_name"`
+		Email    *string `qb:"email"`
+		Age      int     `qb:"age"`
+	}
+
+	q := New(WithTableName("{Users}"), SkipNilWrite(true))
+	q.UpdateWithMask(user{UserKey: 5, UserName: "eaglebush"}, []string{"Email", "user_name"})
+
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	t.Log(s, v)
+
+	// nil email is skipped by SkipNilWrite, age is not in the mask
+	want := "UPDATE Users SET user_name = ? WHERE user_key = ?;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
+
+func TestOriginal(t *testing.T) {
+	type user struct {
+		UserKey  int     `qb:"user_key,key"`
+		UserName string  `qb:"user_name"`
+		Email    *string `qb:"email"`
+		Age      int     `qb:"age"`
+	}
+
+	em := "old@example.com"
+	old := user{UserKey: 5, UserName: "eaglebush", Email: &em, Age: 46}
+	nem := "new@example.com"
+	cur := user{UserKey: 5, UserName: "eaglebush", Email: &nem, Age: 46}
+
+	q := New(WithTableName("{Users}")).Original(old).UpdateStruct(cur)
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	t.Log(s, v)
+	if want := "UPDATE Users SET email = ? WHERE user_key = ?;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	q = New(WithTableName("{Users}")).Original(old).UpdateStruct(old)
+	if _, _, err = q.Build(); !errors.Is(err, ErrNoChanges) {
+		t.Errorf("got %v, want %v", err, ErrNoChanges)
+	}
+}
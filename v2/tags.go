@@ -0,0 +1,76 @@
+package querybuilder
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// Tag adds a tag to the comment block that leads the queries of the builder, such as /* job=nightly, service=billing */,
+// so that the load seen in pg_stat_activity or sys.dm_exec_requests can be attributed. An empty value removes the tag.
+func (qb *QueryBuilder) Tag(key, value string) *QueryBuilder {
+	qb.touch()
+	// the tags are copied, so that the tags of snapshots are not changed
+	tags := make(map[string]string, len(qb.tags)+1)
+	for k, v := range qb.tags {
+		tags[k] = v
+	}
+	if value == "" {
+		delete(tags, key)
+	} else {
+		tags[key] = value
+	}
+	qb.tags = tags
+	return qb
+}
+
+// TagsFromContext adds the tags returned by the function for the context passed to BuildContext
+// to the leading comment block. They replace the tags of Tag with the same keys. Tags with empty values are left out.
+func TagsFromContext(fn CommentFunc) Option {
+	return func(q *QueryBuilder) error {
+		q.tagsFunc = fn
+		return nil
+	}
+}
+
+// prependTags prepends the comment block of the tags to a query. The keys are sorted, and the
+// characters that would end the comment are removed from the keys and values.
+func (qb *QueryBuilder) prependTags(ctx context.Context, query string) string {
+	if len(qb.tags) == 0 && qb.tagsFunc == nil {
+		return query
+	}
+	tags := qb.tags
+	if qb.tagsFunc != nil {
+		tags = make(map[string]string, len(qb.tags))
+		for k, v := range qb.tags {
+			tags[k] = v
+		}
+		for k, v := range qb.tagsFunc(ctx) {
+			if v != "" {
+				tags[k] = v
+			}
+		}
+	}
+	keys := make([]string, 0, len(tags))
+	for k, v := range tags {
+		if v != "" {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return query
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = tagEscape(k) + "=" + tagEscape(tags[k])
+	}
+	return "/* " + strings.Join(pairs, ", ") + " */" + qb.separator() + query
+}
+
+var tagReplacer = strings.NewReplacer("*/", "", "/*", "", "\r", " ", "\n", " ")
+
+// tagEscape removes the comment delimiters and the line breaks from a tag
+func tagEscape(s string) string {
+	return tagReplacer.Replace(s)
+}
@@ -0,0 +1,33 @@
+package querybuilder
+
+import (
+	"context"
+	"testing"
+)
+
+type jobKey struct{}
+
+func TestTags(t *testing.T) {
+	q := New(WithTableName("Users"), TagsFromContext(func(ctx context.Context) map[string]string {
+		job, _ := ctx.Value(jobKey{}).(string)
+		return map[string]string{"job": job}
+	}))
+	q.Tag("service", "billing").Tag("endpoint", "/users */ DROP").Tag("job", "none")
+	q.AddColumn("UserName")
+	s, _, err := q.BuildContext(context.WithValue(context.Background(), jobKey{}, "nightly"))
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "/* endpoint=/users  DROP, job=nightly, service=billing */ SELECT UserName FROM Users;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	snap := q.Snapshot()
+	q.Tag("service", "").Tag("endpoint", "")
+	if s, _, _ = q.Build(); s != "/* job=none */ SELECT UserName FROM Users;" {
+		t.Errorf("unexpected query: %q", s)
+	}
+	if s, _, _ = snap.Builder().Build(); s != "/* endpoint=/users  DROP, job=none, service=billing */ SELECT UserName FROM Users;" {
+		t.Errorf("unexpected snapshot query: %q", s)
+	}
+}
@@ -0,0 +1,30 @@
+package querybuilder
+
+import "strings"
+
+// TempSource sets the table of the query to a temporary table of the dialect. The name is rendered as #name
+// on SQL Server, pg_temp.name on PostgreSQL, temp.name on SQLite and DuckDB, and as is elsewhere, such as on
+// MySQL, where temporary tables shadow the tables of the same name. The name is not interpolated with the schema.
+func (qb *QueryBuilder) TempSource(name string) *QueryBuilder {
+	qb.touch()
+	qb.TableName = strings.Trim(name, "{}")
+	qb.tempTable = true
+	qb.sourceRows, qb.sourceColumns = nil, nil
+	return qb
+}
+
+// TempTableName returns the name of a temporary table in the dialect, as TempSource renders it
+func (qb *QueryBuilder) TempTableName(name string) string {
+	switch qb.Dialect {
+	case SQLSERVER:
+		if strings.HasPrefix(name, "#") {
+			return name
+		}
+		return "#" + name
+	case POSTGRES:
+		return "pg_temp." + name
+	case SQLITE, DUCKDB:
+		return "temp." + name
+	}
+	return name
+}
@@ -0,0 +1,27 @@
+package querybuilder
+
+import "testing"
+
+func TestTempSource(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{SQLSERVER, "INSERT INTO #staging (OrderKey) VALUES (@p1);"},
+		{POSTGRES, "INSERT INTO pg_temp.staging (OrderKey) VALUES ($1);"},
+		{SQLITE, "INSERT INTO temp.staging (OrderKey) VALUES (?);"},
+		{MYSQL, "INSERT INTO staging (OrderKey) VALUES (?);"},
+	}
+	for _, tt := range tests {
+		q := New(WithDialect(tt.dialect), WithSchema("sales"), WithCommand(INSERT))
+		q.TempSource("staging")
+		q.AddValue("OrderKey", 5)
+		s, _, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if s != tt.want {
+			t.Errorf("got %q, want %q", s, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package querybuilder
+
+import (
+	"context"
+	"fmt"
+)
+
+// TenantFunc returns the tenant of a build from its context
+type TenantFunc func(ctx context.Context) interface{}
+
+// Tenant scopes every command of the builder to the tenant of the context. SELECT, UPDATE and DELETE
+// commands are filtered by the tenant column, and INSERT commands set it. Build returns ErrTenant when
+// the context has no tenant or when the tenant column is set by the caller.
+//
+// Unlike the filters of ScopeFilter, the tenant filter is not turned off by Unscoped or WithoutScope.
+// AllTenants is the explicit override.
+func Tenant(column string, fn TenantFunc) Option {
+	return func(q *QueryBuilder) error {
+		if column == "" || fn == nil {
+			return fmt.Errorf("%w: tenant without a column or a function", ErrInvalidOption)
+		}
+		q.tenantColumn = column
+		q.tenantFunc = fn
+		return nil
+	}
+}
+
+// AllTenants sets the query to work across tenants, ignoring Tenant
+func (qb *QueryBuilder) AllTenants() *QueryBuilder {
+	qb.touch()
+	qb.allTenants = true
+	return qb
+}
+
+// tenant returns the tenant of the build, or nil when the builder is not scoped to tenants
+func (qb *QueryBuilder) tenant(ctx context.Context) (interface{}, error) {
+	if qb.tenantFunc == nil || qb.allTenants {
+		return nil, nil
+	}
+	if qb.hasValue(qb.tenantColumn) {
+		return nil, fmt.Errorf("%w: %s is set by the tenant scope", ErrTenant, qb.tenantColumn)
+	}
+	t := realValue(qb.tenantFunc(ctx))
+	if isNil(t) {
+		return nil, fmt.Errorf("%w: no tenant in the context", ErrTenant)
+	}
+	return t, nil
+}
+
+// applyTenant scopes the command to the tenant. The values must be resolved.
+func (qb *QueryBuilder) applyTenant(tenant interface{}) {
+	if isNil(tenant) {
+		return
+	}
+	switch qb.CommandType {
+	case INSERT:
+		qb.addGenerated(queryValue{column: qb.tenantColumn, value: tenant, sqlstring: true})
+	case SELECT, UPDATE, DELETE:
+		qb.addScope(queryFilter{expression: qb.tenantColumn, value: tenant})
+	}
+}
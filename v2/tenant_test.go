@@ -0,0 +1,86 @@
+package querybuilder
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type tenantKey struct{}
+
+func TestTenant(t *testing.T) {
+	tenant := Tenant("tenant_id", func(ctx context.Context) interface{} { return ctx.Value(tenantKey{}) })
+	ctx := context.WithValue(context.Background(), tenantKey{}, 42)
+
+	q := New(WithTableName("Orders"), WithDialect(POSTGRES), tenant)
+	q.AddColumn("OrderKey")
+	q.AddFilter("Status", "A")
+	s, v, err := q.BuildContext(ctx)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT OrderKey FROM Orders WHERE Status = $1 AND tenant_id = $2;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"A", 42}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	// Unscoped does not cross tenants
+	q.Unscoped()
+	q.ParameterOffset = 0
+	if s2, _, _ := q.BuildContext(ctx); s2 != s {
+		t.Errorf("got %q, want %q", s2, s)
+	}
+
+	q = New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(POSTGRES), tenant)
+	q.AddValue("Customer", "ACME")
+	if s, v, err = q.BuildContext(ctx); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (Customer, tenant_id) VALUES ($1,$2);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"ACME", 42}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	// no tenant in the context
+	q = New(WithTableName("Orders"), WithCommand(DELETE), tenant)
+	if _, _, err = q.Build(); !errors.Is(err, ErrTenant) {
+		t.Errorf("expected ErrTenant, got %v", err)
+	}
+	q.AllTenants()
+	if s, _, err = q.Build(); err != nil || s != "DELETE FROM Orders;" {
+		t.Errorf("unexpected cross-tenant delete: %q %v", s, err)
+	}
+
+	// the tenant column is set by the scope
+	q = New(WithTableName("Orders"), WithCommand(UPDATE), tenant)
+	q.AddValue("tenant_id", 7)
+	q.AddFilter("OrderKey", 5)
+	if _, _, err = q.BuildContext(ctx); !errors.Is(err, ErrTenant) {
+		t.Errorf("expected ErrTenant, got %v", err)
+	}
+}
+
+func TestTenantRows(t *testing.T) {
+	tenant := Tenant("tenant_id", func(ctx context.Context) interface{} { return ctx.Value(tenantKey{}) })
+	ctx := context.WithValue(context.Background(), tenantKey{}, 42)
+
+	q := New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(POSTGRES), tenant)
+	q.AddValue("Customer", "ACME")
+	q.AddRow("Globex")
+	q.AddRow("Initech")
+	s, v, err := q.BuildContext(ctx)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (Customer, tenant_id) VALUES ($1,$2),($3,$4),($5,$6);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"ACME", 42, "Globex", 42, "Initech", 42}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
@@ -0,0 +1,147 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Interval is the width of the time buckets of GroupByTimeBucket
+type Interval string
+
+// Interval enum
+const (
+	IntervalMinute Interval = "minute"
+	IntervalHour   Interval = "hour"
+	IntervalDay    Interval = "day"
+	IntervalWeek   Interval = "week" // Weeks start on Monday
+	IntervalMonth  Interval = "month"
+	IntervalYear   Interval = "year"
+)
+
+// GroupByTimeBucket groups the rows by the start of the interval that the time column falls in, for metrics rollups,
+// and adds the start of the interval as a column named after the time column:
+//
+//	q.AddColumn("COUNT(*) AS Orders")
+//	q.GroupByTimeBucket("o.Created", IntervalHour)
+//	// SELECT COUNT(*) AS Orders, date_trunc('hour', o.Created) AS Created ... GROUP BY date_trunc('hour', o.Created)
+//
+// The bucket is rendered for the dialect of the builder when it is built:
+//
+//	POSTGRES, DUCKDB  date_trunc('hour', column)
+//	SNOWFLAKE         DATE_TRUNC('HOUR', column)
+//	BIGQUERY          TIMESTAMP_TRUNC(column, HOUR)
+//	SQLSERVER         DATEADD(hour, DATEDIFF(hour, 0, column), 0)
+//	MYSQL             DATE_FORMAT(column, '%Y-%m-%d %H:00:00')
+//	SQLITE            strftime('%Y-%m-%d %H:00:00', column)
+//	ORACLE            TRUNC(column, 'HH24')
+//
+// Until then, the column and the group hold TIME_BUCKET(hour, o.Created). The MySQL and SQLite buckets are strings.
+// An unknown interval is returned as ErrInvalidOption by Build, and the GENERIC dialect returns ErrNotSupported.
+func (qb *QueryBuilder) GroupByTimeBucket(column string, interval Interval) *QueryBuilder {
+	qb.touch()
+	b := timeBucket{column: column, interval: interval}
+	if _, err := b.render(POSTGRES); err != nil {
+		qb.err = joinErrors(qb.err, err)
+		return qb
+	}
+	// the buckets are copied, so that the buckets of snapshots are not changed
+	qb.buckets = append(qb.buckets[:len(qb.buckets):len(qb.buckets)], b)
+	alias := column
+	if i := strings.LastIndex(alias, "."); i >= 0 {
+		alias = alias[i+1:]
+	}
+	qb.AddColumn(b.String() + " AS " + alias)
+	return qb.AddGroup(b.String())
+}
+
+// timeBucket is a time bucket added by GroupByTimeBucket
+type timeBucket struct {
+	column   string
+	interval Interval
+}
+
+// String returns the text that holds the place of the bucket in the columns and the group until it is rendered
+func (b timeBucket) String() string {
+	return "TIME_BUCKET(" + string(b.interval) + ", " + b.column + ")"
+}
+
+// renderBuckets replaces the time buckets of the columns and the group with their expressions for the dialect.
+// The values must be resolved.
+func (qb *QueryBuilder) renderBuckets() error {
+	if len(qb.buckets) == 0 {
+		return nil
+	}
+	group := append([]string(nil), qb.Group...)
+	for _, b := range qb.buckets {
+		expr, err := b.render(qb.Dialect)
+		if err != nil {
+			return err
+		}
+		held := b.String()
+		for i, v := range qb.Values {
+			if strings.HasPrefix(v.column, held+" AS ") {
+				qb.Values[i].column = expr + strings.TrimPrefix(v.column, held)
+			}
+		}
+		for i, g := range group {
+			if g == held {
+				group[i] = expr
+			}
+		}
+	}
+	qb.Group = group
+	return nil
+}
+
+// render returns the start of the interval of the time column for the dialect
+func (b timeBucket) render(d Dialect) (string, error) {
+	column, interval := b.column, b.interval
+	var oracle, mysql, sqlite string
+	switch interval {
+	case IntervalMinute:
+		oracle, mysql, sqlite = "MI", "%Y-%m-%d %H:%i:00", "%Y-%m-%d %H:%M:00"
+	case IntervalHour:
+		oracle, mysql, sqlite = "HH24", "%Y-%m-%d %H:00:00", "%Y-%m-%d %H:00:00"
+	case IntervalDay:
+		oracle, mysql, sqlite = "DD", "%Y-%m-%d", "%Y-%m-%d"
+	case IntervalWeek:
+		oracle = "IW"
+	case IntervalMonth:
+		oracle, mysql, sqlite = "MM", "%Y-%m-01", "%Y-%m-01"
+	case IntervalYear:
+		oracle, mysql, sqlite = "YYYY", "%Y-01-01", "%Y-01-01"
+	default:
+		return "", fmt.Errorf("%w: time bucket interval %q of %s", ErrInvalidOption, interval, column)
+	}
+	unit := string(interval)
+	switch d {
+	case POSTGRES, DUCKDB:
+		return "date_trunc('" + unit + "', " + column + ")", nil
+	case SNOWFLAKE:
+		return "DATE_TRUNC('" + strings.ToUpper(unit) + "', " + column + ")", nil
+	case BIGQUERY:
+		if interval == IntervalWeek {
+			return "TIMESTAMP_TRUNC(" + column + ", ISOWEEK)", nil
+		}
+		return "TIMESTAMP_TRUNC(" + column + ", " + strings.ToUpper(unit) + ")", nil
+	case SQLSERVER:
+		// day 0 is 1900-01-01, a Monday. DATEDIFF counts the weeks from Sunday, so Sundays are moved to the week before.
+		if interval == IntervalWeek {
+			return "DATEADD(week, DATEDIFF(week, 0, DATEADD(day, -1, " + column + ")), 0)", nil
+		}
+		return "DATEADD(" + unit + ", DATEDIFF(" + unit + ", 0, " + column + "), 0)", nil
+	case MYSQL:
+		if interval == IntervalWeek {
+			return "DATE_FORMAT(DATE_SUB(" + column + ", INTERVAL WEEKDAY(" + column + ") DAY), '%Y-%m-%d')", nil
+		}
+		return "DATE_FORMAT(" + column + ", '" + mysql + "')", nil
+	case SQLITE:
+		if interval == IntervalWeek {
+			return "date(" + column + ", '-6 days', 'weekday 1')", nil
+		}
+		return "strftime('" + sqlite + "', " + column + ")", nil
+	case ORACLE:
+		return "TRUNC(" + column + ", '" + oracle + "')", nil
+	}
+	return "", fmt.Errorf("%w: time buckets on %s", ErrNotSupported, d)
+}
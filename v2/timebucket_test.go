@@ -0,0 +1,72 @@
+package querybuilder
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGroupByTimeBucket(t *testing.T) {
+	tests := []struct {
+		dialect  Dialect
+		interval Interval
+		want     string
+	}{
+		{POSTGRES, IntervalHour, "date_trunc('hour', o.Created)"},
+		{SNOWFLAKE, IntervalDay, "DATE_TRUNC('DAY', o.Created)"},
+		{BIGQUERY, IntervalWeek, "TIMESTAMP_TRUNC(o.Created, ISOWEEK)"},
+		{SQLSERVER, IntervalMonth, "DATEADD(month, DATEDIFF(month, 0, o.Created), 0)"},
+		{SQLSERVER, IntervalWeek, "DATEADD(week, DATEDIFF(week, 0, DATEADD(day, -1, o.Created)), 0)"},
+		{MYSQL, IntervalMinute, "DATE_FORMAT(o.Created, '%Y-%m-%d %H:%i:00')"},
+		{SQLITE, IntervalYear, "strftime('%Y-01-01', o.Created)"},
+		{ORACLE, IntervalHour, "TRUNC(o.Created, 'HH24')"},
+	}
+	for _, tt := range tests {
+		q := New(WithTableName("Orders o"), WithDialect(tt.dialect))
+		q.AddColumn("COUNT(*) AS Orders")
+		q.GroupByTimeBucket("o.Created", tt.interval)
+		s, _, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		want := "SELECT COUNT(*) AS Orders, " + tt.want + " AS Created FROM Orders o GROUP BY " + tt.want
+		if tt.dialect != ORACLE {
+			want += ";"
+		}
+		if s != want {
+			t.Errorf("%s: got %q, want %q", tt.dialect, s, want)
+		}
+	}
+
+	// the bucket is rendered for the dialect at build time
+	q := New(WithTableName("Orders"))
+	q.AddColumn("COUNT(*) AS Orders")
+	q.GroupByTimeBucket("Created", IntervalDay)
+	snap := q.Snapshot()
+	if err := WithDialect(ORACLE)(q); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT COUNT(*) AS Orders, TRUNC(Created, 'DD') AS Created FROM Orders GROUP BY TRUNC(Created, 'DD')"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if _, _, err := snap.Build(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("got %v, want %v", err, ErrNotSupported)
+	}
+
+	q = New(WithTableName("Orders"), WithDialect(POSTGRES))
+	q.AddColumn("COUNT(*) AS Orders")
+	q.GroupByTimeBucket("Created", "fortnight")
+	if _, _, err := q.Build(); !errors.Is(err, ErrInvalidOption) {
+		t.Errorf("got %v, want %v", err, ErrInvalidOption)
+	}
+
+	q = New(WithTableName("Orders"))
+	q.AddColumn("COUNT(*) AS Orders")
+	q.GroupByTimeBucket("Created", IntervalDay)
+	if _, _, err := q.Build(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("got %v, want %v", err, ErrNotSupported)
+	}
+}
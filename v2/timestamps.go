@@ -0,0 +1,107 @@
+package querybuilder
+
+import (
+	"strings"
+	"time"
+)
+
+// nowFunc returns the current time of bound timestamps
+var nowFunc = time.Now
+
+// currentTimestamps are the current time functions of the dialects. Other dialects use CURRENT_TIMESTAMP.
+var currentTimestamps = map[Dialect]string{
+	SQLSERVER: "GETDATE()",
+	POSTGRES:  "NOW()",
+	MYSQL:     "NOW()",
+	ORACLE:    "SYSTIMESTAMP",
+}
+
+// Timestamps sets the columns that record the creation and the last update of a row. INSERT commands set
+// both columns, and UPDATE commands set the updated column, unless the values are added to the builder.
+// Upserts keep the creation time of an existing row. Either column can be empty.
+//
+// The current time is bound as a parameter. WithServerTimestamps renders the current time function of the database instead.
+func Timestamps(created, updated string) Option {
+	return func(q *QueryBuilder) error {
+		q.createdColumn = created
+		q.updatedColumn = updated
+		return nil
+	}
+}
+
+// WithServerTimestamps sets the condition to render the timestamps with the current time function of the dialect,
+// such as NOW() or GETDATE(), instead of binding the time of the client
+func WithServerTimestamps(enabled bool) Option {
+	return func(q *QueryBuilder) error {
+		q.serverTime = enabled
+		return nil
+	}
+}
+
+// addTimestamps adds the timestamp values of the command. The values must be resolved.
+func (qb *QueryBuilder) addTimestamps() {
+	var cols []string
+	switch qb.CommandType {
+	case INSERT:
+		cols = []string{qb.createdColumn, qb.updatedColumn}
+	case UPDATE:
+		cols = []string{qb.updatedColumn}
+		// an UPDATE command without changes stays without changes
+		if qb.original != nil && !qb.writes() {
+			return
+		}
+	default:
+		return
+	}
+	var value interface{} = nowFunc()
+	if qb.serverTime {
+		value = qb.currentTimestamp()
+	}
+	for _, c := range cols {
+		if c == "" || qb.hasValue(c) {
+			continue
+		}
+		qb.addGenerated(queryValue{
+			column:    c,
+			value:     value,
+			sqlstring: !qb.serverTime,
+			expr:      qb.serverTime,
+		})
+	}
+}
+
+// addGenerated adds a value generated by the builder, which is written in every row. The columns and the values are copied
+// so that the builder is left unchanged.
+func (qb *QueryBuilder) addGenerated(v queryValue) {
+	v.generated = true
+	qb.Columns = append(qb.Columns[:len(qb.Columns):len(qb.Columns)], QueryColumn{Name: v.column, Length: 8000})
+	qb.Values = append(qb.Values[:len(qb.Values):len(qb.Values)], v)
+}
+
+// currentTimestamp returns the current time function of the dialect
+func (qb *QueryBuilder) currentTimestamp() string {
+	if fn, ok := currentTimestamps[qb.Dialect]; ok {
+		return fn
+	}
+	return "CURRENT_TIMESTAMP"
+}
+
+// hasValue reports whether a value is added for the column
+func (qb *QueryBuilder) hasValue(column string) bool {
+	for _, v := range qb.Values {
+		if strings.EqualFold(v.column, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// writes reports whether the command writes any value
+func (qb *QueryBuilder) writes() bool {
+	for _, v := range qb.Values {
+		if !v.skip || v.forcenull {
+			return true
+		}
+	}
+	return false
+}
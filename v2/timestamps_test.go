@@ -0,0 +1,97 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimestamps(t *testing.T) {
+	now := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	nowFunc = func() time.Time { return now }
+	defer func() { nowFunc = time.Now }()
+
+	q := New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(POSTGRES), Timestamps("created_at", "updated_at"))
+	q.AddValue("Customer", "ACME")
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (Customer, created_at, updated_at) VALUES ($1,$2,$3);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"ACME", now, now}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+	if len(q.Values) != 1 || len(q.Columns) != 1 {
+		t.Errorf("builder values were changed: %v", q.Values)
+	}
+
+	// a value added by the caller is kept
+	q = New(WithTableName("Orders"), WithCommand(UPDATE), WithDialect(SQLSERVER), Timestamps("created_at", "updated_at"), WithServerTimestamps(true))
+	q.AddValue("Customer", "ACME")
+	q.AddFilter("OrderKey", 5)
+	if s, v, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "UPDATE Orders SET Customer = @p1, updated_at = GETDATE() WHERE OrderKey = @p2;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"ACME", 5}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	// upserts keep the creation time of the existing row
+	q = New(WithTableName("Orders"), WithDialect(POSTGRES), Timestamps("created_at", "updated_at"), WithServerTimestamps(true))
+	q.Upsert("OrderKey")
+	q.AddValue("OrderKey", 5)
+	if s, _, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (OrderKey, created_at, updated_at) VALUES ($1,NOW(),NOW()) ON CONFLICT (OrderKey) DO UPDATE SET updated_at = EXCLUDED.updated_at;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+
+	// an UPDATE command without changes stays without changes
+	q = New(WithTableName("Orders"), WithCommand(UPDATE), Timestamps("", "updated_at"))
+	q.Original(map[string]interface{}{"Customer": "ACME"})
+	q.AddValue("Customer", "ACME")
+	q.AddFilter("OrderKey", 5)
+	if _, _, err = q.Build(); !errors.Is(err, ErrNoChanges) {
+		t.Errorf("got %v, want %v", err, ErrNoChanges)
+	}
+}
+
+func TestTimestampsRows(t *testing.T) {
+	now := time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC)
+	nowFunc = func() time.Time { return now }
+	defer func() { nowFunc = time.Now }()
+
+	q := New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(POSTGRES), Timestamps("created_at", "updated_at"))
+	q.AddValue("Customer", "ACME")
+	q.AddRow("Globex")
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (Customer, created_at, updated_at) VALUES ($1,$2,$3),($4,$5,$6);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"ACME", now, now, "Globex", now, now}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	q = New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(SQLSERVER), Timestamps("created_at", ""), WithServerTimestamps(true))
+	q.AddValue("Customer", "ACME")
+	q.AddRow("Globex")
+	if s, v, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (Customer, created_at) VALUES (@p1,GETDATE()),(@p2,GETDATE());"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{"ACME", "Globex"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
@@ -0,0 +1,32 @@
+package querybuilder
+
+import (
+	"fmt"
+	"time"
+)
+
+// WithTimeLocation sets the location that the time values are converted to before they are bound, so that
+// the application servers of different time zones write consistent timestamps. The monotonic clock reading
+// of the times is stripped. It applies to every argument of the query, including those of the filter functions.
+func WithTimeLocation(loc *time.Location) Option {
+	return func(q *QueryBuilder) error {
+		if loc == nil {
+			return fmt.Errorf("%w: nil time location", ErrInvalidOption)
+		}
+		q.timeLocation = loc
+		return nil
+	}
+}
+
+// WithUTC converts the time values to UTC before they are bound, like WithTimeLocation(time.UTC)
+func WithUTC() Option {
+	return WithTimeLocation(time.UTC)
+}
+
+// normalizeTime converts a time argument to the location of the builder
+func (qb *QueryBuilder) normalizeTime(arg interface{}) interface{} {
+	if t, ok := arg.(time.Time); ok && qb.timeLocation != nil {
+		return t.In(qb.timeLocation).Round(0)
+	}
+	return arg
+}
@@ -0,0 +1,38 @@
+package querybuilder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWithTimeLocation(t *testing.T) {
+	manila := time.FixedZone("PHT", 8*3600)
+	local := time.Date(2024, 3, 1, 8, 30, 0, 0, manila)
+	q := New(WithTableName("Events"), WithCommand(INSERT), WithUTC())
+	q.AddValue("Created", &local)
+	q.AddRow(local.Add(time.Hour))
+	_, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if len(v) != 2 {
+		t.Fatalf("unexpected args: %v", v)
+	}
+	for i, want := range []string{"2024-03-01T00:30:00Z", "2024-03-01T01:30:00Z"} {
+		ts, ok := v[i].(time.Time)
+		if !ok || ts.Location() != time.UTC || ts.Format(time.RFC3339) != want {
+			t.Errorf("argument %d: got %v, want %s", i+1, v[i], want)
+		}
+	}
+
+	now := time.Now()
+	q = New(WithTableName("Events"), WithTimeLocation(manila))
+	q.AddColumn("Name")
+	q.AddFilter("Created", now)
+	if _, v, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if ts := v[0].(time.Time); ts.Location() != manila || ts != ts.Round(0) || !ts.Equal(now) {
+		t.Errorf("unexpected time: %v", ts)
+	}
+}
@@ -0,0 +1,74 @@
+package querybuilder
+
+import "strings"
+
+// TokenResolver returns the value of a named token of a table name, such as {prefix} or the db
+// part of {db.dbo.Orders}. It returns false for the names it does not know, which are tables.
+type TokenResolver func(name string) (string, bool)
+
+// WithTokenResolver sets the resolver of the named tokens of the interpolated table names
+func WithTokenResolver(resolve TokenResolver) Option {
+	return func(q *QueryBuilder) error {
+		q.tokenResolver = resolve
+		return nil
+	}
+}
+
+// InterpolateTableFunc works like InterpolateTable, and replaces the tokens known by the resolver.
+//
+// A token that is resolved as a whole, such as {schema} or {prefix}, is replaced by its value as is, so that
+// {schema}.Orders and {prefix}Orders can be templated. The parts of a multi-part token, such as {db.dbo.Orders},
+// are resolved one by one, and the token is not prepended with the schema.
+func InterpolateTableFunc(sql string, schema string, resolve TokenResolver) string {
+	if schema != "" {
+		schema = schema + `.`
+	}
+	return interpolateTables(sql, plainTables, func(table string) string {
+		if v, ok := resolveToken(table, resolve); ok {
+			return v
+		}
+		if parts := splitParts(table); len(parts) > 1 {
+			return strings.Join(resolveParts(parts, resolve), ".")
+		}
+		return schema + table
+	})
+}
+
+// InterpolateTableQuotedFunc works like InterpolateTableQuoted, and replaces the tokens known by the resolver
+// as InterpolateTableFunc does. The resolved parts of a multi-part token are quoted, while a token resolved
+// as a whole is not.
+func InterpolateTableQuotedFunc(sql string, schema string, escapeChar string, resolve TokenResolver) string {
+	ec := ParseReserveWordsChars(escapeChar)
+	qs := quoteParts(schema, ec)
+	return interpolateTables(sql, quotedTables, func(table string) string {
+		if v, ok := resolveToken(table, resolve); ok {
+			return v
+		}
+		parts := splitParts(table)
+		if len(parts) > 1 {
+			return quoteParts(strings.Join(resolveParts(parts, resolve), "."), ec)
+		}
+		qt := quoteParts(table, ec)
+		if qs == "" {
+			return qt
+		}
+		return qs + "." + qt
+	})
+}
+
+func resolveToken(name string, resolve TokenResolver) (string, bool) {
+	if resolve == nil {
+		return "", false
+	}
+	return resolve(name)
+}
+
+// resolveParts replaces the parts known by the resolver
+func resolveParts(parts []string, resolve TokenResolver) []string {
+	for i, p := range parts {
+		if v, ok := resolveToken(p, resolve); ok {
+			parts[i] = v
+		}
+	}
+	return parts
+}
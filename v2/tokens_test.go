@@ -0,0 +1,45 @@
+package querybuilder
+
+import "testing"
+
+func TestInterpolateTableFunc(t *testing.T) {
+	resolve := func(name string) (string, bool) {
+		switch name {
+		case "db":
+			return "Archive2024", true
+		case "schema":
+			return "tenant_7", true
+		case "prefix":
+			return "app_", true
+		}
+		return "", false
+	}
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{"SELECT * FROM {Orders}", "SELECT * FROM sales.Orders"},
+		{"SELECT * FROM {db.dbo.Orders}", "SELECT * FROM Archive2024.dbo.Orders"},
+		{"SELECT * FROM {schema}.Orders", "SELECT * FROM tenant_7.Orders"},
+		{"SELECT * FROM {prefix}Orders", "SELECT * FROM app_Orders"},
+		{"SELECT {ops.Orders}.total FROM {ops.Orders} o", "SELECT o.total FROM ops.Orders o"},
+	}
+	for _, tt := range tests {
+		if got := InterpolateTableFunc(tt.sql, "sales", resolve); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+	if got, want := InterpolateTableQuotedFunc("SELECT * FROM {db.dbo.Orders}", "sales", "[]", resolve), "SELECT * FROM [Archive2024].[dbo].[Orders]"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	q := New(WithTableName("{db.dbo.Orders}"), WithTokenResolver(resolve), ValidateIdentifiers(nil))
+	q.AddColumn("total")
+	s, _, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT total FROM Archive2024.dbo.Orders;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
@@ -0,0 +1,208 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Logic is the logical operator of a condition tree
+type Logic string
+
+// Logic enum
+const (
+	LogicAnd Logic = "AND"
+	LogicOr  Logic = "OR"
+	LogicNot Logic = "NOT"
+)
+
+// Node is a condition or a condition tree
+type Node interface {
+	node() Tree
+}
+
+// Tree is a tree of conditions joined by AND, OR and NOT. A tree without a logical operator is a single condition.
+//
+// Trees are built with And, Or and Not, independently of a query builder, so that they can be reused
+// across builders. They can be serialized to JSON, although the numbers of the values then become float64.
+type Tree struct {
+	Logic Logic  `json:"logic,omitempty"` // Logical operator of the nodes
+	Cond  *Cond  `json:"cond,omitempty"`  // Condition of a leaf
+	Nodes []Tree `json:"nodes,omitempty"` // Nodes joined by the logical operator
+}
+
+func (t Tree) node() Tree {
+	return t
+}
+
+func (c Cond) node() Tree {
+	return Tree{Cond: &c}
+}
+
+// And joins the nodes with AND. An empty AND matches all rows.
+func And(nodes ...Node) Tree {
+	return Tree{Logic: LogicAnd, Nodes: trees(nodes)}
+}
+
+// Or joins the nodes with OR. An empty OR matches no rows.
+func Or(nodes ...Node) Tree {
+	return Tree{Logic: LogicOr, Nodes: trees(nodes)}
+}
+
+// Not negates a node
+func Not(n Node) Tree {
+	return Tree{Logic: LogicNot, Nodes: []Tree{n.node()}}
+}
+
+// NotGroup negates a group of nodes joined by AND, rendered as NOT (a AND b)
+func NotGroup(nodes ...Node) Tree {
+	return Not(And(nodes...))
+}
+
+func trees(nodes []Node) []Tree {
+	t := make([]Tree, len(nodes))
+	for i, n := range nodes {
+		t[i] = n.node()
+	}
+	return t
+}
+
+// WhereTree adds a condition tree as a filter. The tree is copied, so later changes to it do not affect the builder.
+// A tree with an unknown operator or logic is returned as ErrInvalidOperator by Build.
+func (qb *QueryBuilder) WhereTree(n Node) *QueryBuilder {
+	qb.touch()
+	t := n.node().copy(nil)
+	if err := t.check(); err != nil {
+		if qb.err == nil {
+			qb.err = err
+		}
+		return qb
+	}
+	qb.Filter = append(qb.Filter, queryFilter{tree: &t})
+	return qb
+}
+
+// AddFilterNot adds a filter that negates a group of nodes joined by AND, such as
+// NOT (Status = ? AND Age > ?). An empty group matches no rows.
+func (qb *QueryBuilder) AddFilterNot(nodes ...Node) *QueryBuilder {
+	return qb.WhereTree(NotGroup(nodes...))
+}
+
+// copy returns a deep copy of the tree with the values mapped by fn
+func (t Tree) copy(fn func(interface{}) interface{}) Tree {
+	c := Tree{Logic: t.Logic}
+	if t.Cond != nil {
+		cond := *t.Cond
+		if vals, ok := cond.Value.([]interface{}); ok {
+			c := make([]interface{}, len(vals))
+			for i, v := range vals {
+				if fn != nil {
+					v = fn(v)
+				}
+				c[i] = v
+			}
+			cond.Value = c
+		} else if fn != nil {
+			cond.Value = fn(cond.Value)
+		}
+		c.Cond = &cond
+	}
+	if t.Nodes != nil {
+		c.Nodes = make([]Tree, len(t.Nodes))
+		for i, n := range t.Nodes {
+			c.Nodes[i] = n.copy(fn)
+		}
+	}
+	return c
+}
+
+// check returns the error of an unknown operator or logic
+func (t Tree) check() error {
+	if t.Cond != nil {
+		if !t.Cond.Operator.valid() {
+			return fmt.Errorf("%w: %q on %s", ErrInvalidOperator, t.Cond.Operator, t.Cond.Column)
+		}
+		return nil
+	}
+	switch t.Logic {
+	case LogicAnd, LogicOr:
+	case LogicNot:
+		if len(t.Nodes) != 1 {
+			return fmt.Errorf("%w: NOT of %d nodes", ErrInvalidOperator, len(t.Nodes))
+		}
+	default:
+		return fmt.Errorf("%w: logic %q", ErrInvalidOperator, t.Logic)
+	}
+	for _, n := range t.Nodes {
+		if err := n.check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conds calls fn for the conditions of the tree in the order of their placeholders
+func (t Tree) conds(fn func(c Cond)) {
+	if t.Cond != nil {
+		fn(*t.Cond)
+		return
+	}
+	for _, n := range t.Nodes {
+		n.conds(fn)
+	}
+}
+
+// treeClause renders a condition tree
+func (qb *QueryBuilder) treeClause(t Tree, paramcnt, phcnt *int) string {
+	if t.Cond != nil {
+		c := t.Cond
+		return qb.condClause(queryFilter{expression: c.Column, value: c.Value, op: c.Operator, ci: c.CaseInsensitive, escape: c.Escaped}, paramcnt, phcnt)
+	}
+	if t.Logic == LogicNot {
+		n := t.Nodes[0]
+		// a group of nodes is already enclosed in parentheses
+		if (n.Logic == LogicAnd || n.Logic == LogicOr) && len(n.Nodes) > 1 {
+			return "NOT " + qb.treeClause(n, paramcnt, phcnt)
+		}
+		return "NOT (" + qb.treeClause(n, paramcnt, phcnt) + ")"
+	}
+	switch len(t.Nodes) {
+	case 0:
+		if t.Logic == LogicOr {
+			return "1 = 0"
+		}
+		return "1 = 1"
+	case 1:
+		return qb.treeClause(t.Nodes[0], paramcnt, phcnt)
+	}
+	parts := make([]string, len(t.Nodes))
+	for i, n := range t.Nodes {
+		parts[i] = qb.treeClause(n, paramcnt, phcnt)
+	}
+	return "(" + strings.Join(parts, " "+string(t.Logic)+" ") + ")"
+}
+
+// treeShape writes the shape of a condition tree for the query cache
+func treeShape(sb *strings.Builder, t Tree) {
+	if t.Cond != nil {
+		fmt.Fprintf(sb, "(%q%s%t%t%t", t.Cond.Column, t.Cond.Operator, t.Cond.CaseInsensitive, t.Cond.Escaped, isNil(t.Cond.Value))
+		if t.Cond.Operator == OpIn {
+			for _, v := range inValues(t.Cond.Value) {
+				fmt.Fprintf(sb, "%t", isNil(v))
+			}
+		}
+		sb.WriteString(")")
+		return
+	}
+	sb.WriteString(string(t.Logic) + "(")
+	for _, n := range t.Nodes {
+		treeShape(sb, n)
+	}
+	sb.WriteString(")")
+}
+
+// bound reports whether the tree has conditions with values
+func (t Tree) bound() bool {
+	b := false
+	t.conds(func(c Cond) { b = b || !isNil(c.Value) && (c.Operator != OpIn || len(inValues(c.Value)) > 0) })
+	return b
+}
@@ -0,0 +1,66 @@
+package querybuilder
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWhereTree(t *testing.T) {
+	active := And(Eq("Status", "A"), Or(Gt("Age", 18), Not(IsNull("GuardianKey"))))
+
+	q := New(WithTableName("Users"), WithDialect(POSTGRES))
+	q.AddColumn("UserName")
+	q.AddFilter("GroupKey", 7)
+	q.WhereTree(active)
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT UserName FROM Users WHERE GroupKey = $1 AND (Status = $2 AND (Age > $3 OR NOT (GuardianKey IS NULL)));"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{7, "A", 18}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	// the tree survives a round-trip through JSON
+	b, err := json.Marshal(active)
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	var tree Tree
+	if err = json.Unmarshal(b, &tree); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	q = New(WithTableName("Users"), WithCommand(DELETE), WithDialect(POSTGRES))
+	q.WhereTree(tree)
+	if s, _, _ = q.Build(); s != "DELETE FROM Users WHERE (Status = $1 AND (Age > $2 OR NOT (GuardianKey IS NULL)));" {
+		t.Errorf("unexpected query: %q", s)
+	}
+
+	q.WhereTree(Tree{Logic: "XOR"})
+	if _, _, err = q.Build(); !errors.Is(err, ErrInvalidOperator) {
+		t.Errorf("got %v, want %v", err, ErrInvalidOperator)
+	}
+}
+
+func TestAddFilterNot(t *testing.T) {
+	q := New(WithTableName("Users"), WithCommand(UPDATE), WithDialect(POSTGRES))
+	q.AddValue("Active", false)
+	q.AddFilterNot(Eq("Status", "A"), Or(Gt("Age", 18), NotNull("GuardianKey")))
+	q.AddFilterNot(Eq("Locked", true))
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "UPDATE Users SET Active = $1 WHERE NOT (Status = $2 AND (Age > $3 OR GuardianKey IS NOT NULL)) AND NOT (Locked = $4);"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{false, "A", 18, true}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
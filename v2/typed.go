@@ -0,0 +1,45 @@
+package querybuilder
+
+// Typed is a QueryBuilder bound to the struct type T. The columns and values
+// are mapped from the fields of T the same way as FromStruct.
+type Typed[T any] struct {
+	*QueryBuilder
+}
+
+// Insert creates a typed INSERT builder for the table
+func Insert[T any](table string, options ...Option) *Typed[T] {
+	return newTyped[T](table, INSERT, options)
+}
+
+// Update creates a typed UPDATE builder for the table
+func Update[T any](table string, options ...Option) *Typed[T] {
+	return newTyped[T](table, UPDATE, options)
+}
+
+// Select creates a typed SELECT builder for the table with a column for each field of T
+func Select[T any](table string, options ...Option) *Typed[T] {
+	t := newTyped[T](table, SELECT, options)
+	SelectStruct[T](t.QueryBuilder)
+	return t
+}
+
+func newTyped[T any](table string, ct Command, options []Option) *Typed[T] {
+	opts := append([]Option{WithTableName(table), WithCommand(ct)}, options...)
+	return &Typed[T]{QueryBuilder: New(opts...)}
+}
+
+// Bind adds the fields of v as values. On UPDATE builders, the key fields are added as filters.
+func (t *Typed[T]) Bind(v T, vcOpts ...ValueOption) *Typed[T] {
+	if t.CommandType == UPDATE {
+		t.UpdateStruct(v, vcOpts...)
+		return t
+	}
+	t.FromStruct(v, vcOpts...)
+	return t
+}
+
+// BindMask adds only the fields of v named in the mask as values. The key fields are added as filters.
+func (t *Typed[T]) BindMask(v T, fields []string, vcOpts ...ValueOption) *Typed[T] {
+	t.UpdateWithMask(v, fields, vcOpts...)
+	return t
+}
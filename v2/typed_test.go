@@ -0,0 +1,36 @@
+package querybuilder
+
+import "testing"
+
+func TestTypedBuilders(t *testing.T) {
+	type user struct {
+		UserKey  int    `qb:"user_key,key"`
+		UserName string `qb:"user_name"`
+	}
+
+	s, v, err := Insert[user]("{Users}").Bind(user{UserKey: 5, UserName: "eaglebush"}).Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Users (user_key, user_name) VALUES (?,?);"; s != want || len(v) != 2 {
+		t.Errorf("got %q %v, want %q", s, v, want)
+	}
+
+	s, v, err = Update[user]("{Users}").Bind(user{UserKey: 5, UserName: "eaglebush"}).Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "UPDATE Users SET user_name = ? WHERE user_key = ?;"; s != want || len(v) != 2 {
+		t.Errorf("got %q %v, want %q", s, v, want)
+	}
+
+	sel := Select[user]("{Users}")
+	sel.AddFilter("user_key", 5)
+	s, _, err = sel.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT user_key, user_name FROM Users WHERE user_key = ?;"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+}
@@ -0,0 +1,95 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Upsert sets the command to INSERT and updates the existing row when the key columns conflict.
+//
+// It renders ON CONFLICT on PostgreSQL, SQLite and DuckDB, ON DUPLICATE KEY UPDATE on MySQL,
+// and MERGE on SQL Server, Oracle and Snowflake. The non-key values are set on the existing row.
+func (qb *QueryBuilder) Upsert(keys ...string) *QueryBuilder {
+	qb.touch()
+	qb.CommandType = INSERT
+	qb.UpsertKeys = append(qb.UpsertKeys, keys...)
+	return qb
+}
+
+// upsertClause returns the clause appended to the INSERT statement, or the complete
+// statement when the dialect upserts with MERGE
+func (qb *QueryBuilder) upsertClause(table string, cols, vals []string) (merge bool, clause string, err error) {
+	upd := make([]string, 0, len(cols))
+	for _, c := range cols {
+		// the creation time and the creator of the existing row are kept
+		if !qb.isUpsertKey(c) && !strings.EqualFold(c, qb.createdColumn) && !strings.EqualFold(c, qb.createdByColumn) {
+			upd = append(upd, c)
+		}
+	}
+	switch qb.Dialect {
+	case POSTGRES, SQLITE, DUCKDB:
+		if len(upd) == 0 {
+			return false, " ON CONFLICT (" + strings.Join(qb.UpsertKeys, ", ") + ") DO NOTHING", nil
+		}
+		set := make([]string, len(upd))
+		for i, c := range upd {
+			set[i] = c + " = EXCLUDED." + c
+		}
+		return false, " ON CONFLICT (" + strings.Join(qb.UpsertKeys, ", ") + ") DO UPDATE SET " + strings.Join(set, ", "), nil
+	case MYSQL:
+		if len(upd) == 0 {
+			return false, " ON DUPLICATE KEY UPDATE " + qb.UpsertKeys[0] + " = " + qb.UpsertKeys[0], nil
+		}
+		set := make([]string, len(upd))
+		for i, c := range upd {
+			set[i] = c + " = VALUES(" + c + ")"
+		}
+		return false, " ON DUPLICATE KEY UPDATE " + strings.Join(set, ", "), nil
+	case SQLSERVER, ORACLE, SNOWFLAKE:
+		return true, qb.mergeStatement(table, cols, vals, upd), nil
+	}
+	return false, "", fmt.Errorf("%w: upsert on %s", ErrNotSupported, qb.Dialect)
+}
+
+// mergeStatement renders a MERGE statement that matches the source row by the key columns
+func (qb *QueryBuilder) mergeStatement(table string, cols, vals, upd []string) string {
+	var sb strings.Builder
+	sb.WriteString("MERGE INTO " + table + " t USING (SELECT ")
+	for i, c := range cols {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(vals[i] + " AS " + c)
+	}
+	if qb.Dialect == ORACLE {
+		sb.WriteString(" FROM DUAL")
+	}
+	sb.WriteString(") s ON (")
+	for i, k := range qb.UpsertKeys {
+		if i > 0 {
+			sb.WriteString(" AND ")
+		}
+		sb.WriteString("t." + k + " = s." + k)
+	}
+	sb.WriteString(")")
+	if len(upd) > 0 {
+		sb.WriteString(" WHEN MATCHED THEN UPDATE SET ")
+		for i, c := range upd {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString("t." + c + " = s." + c)
+		}
+	}
+	sb.WriteString(" WHEN NOT MATCHED THEN INSERT (" + strings.Join(cols, ", ") + ") VALUES (s." + strings.Join(cols, ", s.") + ")")
+	return sb.String()
+}
+
+func (qb *QueryBuilder) isUpsertKey(column string) bool {
+	for _, k := range qb.UpsertKeys {
+		if strings.EqualFold(k, column) {
+			return true
+		}
+	}
+	return false
+}
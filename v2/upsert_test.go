@@ -0,0 +1,32 @@
+package querybuilder
+
+import "testing"
+
+func TestUpsert(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{POSTGRES, "INSERT INTO Users (UserKey, UserName) VALUES ($1,$2) ON CONFLICT (UserKey) DO UPDATE SET UserName = EXCLUDED.UserName;"},
+		{MYSQL, "INSERT INTO Users (UserKey, UserName) VALUES (?,?) ON DUPLICATE KEY UPDATE UserName = VALUES(UserName);"},
+		{SQLSERVER, "MERGE INTO Users t USING (SELECT @p1 AS UserKey, @p2 AS UserName) s ON (t.UserKey = s.UserKey) WHEN MATCHED THEN UPDATE SET t.UserName = s.UserName WHEN NOT MATCHED THEN INSERT (UserKey, UserName) VALUES (s.UserKey, s.UserName);"},
+		{ORACLE, "MERGE INTO Users t USING (SELECT :1 AS UserKey, :2 AS UserName FROM DUAL) s ON (t.UserKey = s.UserKey) WHEN MATCHED THEN UPDATE SET t.UserName = s.UserName WHEN NOT MATCHED THEN INSERT (UserKey, UserName) VALUES (s.UserKey, s.UserName)"},
+	}
+	for _, tt := range tests {
+		q := New(WithTableName("Users"), WithDialect(tt.dialect))
+		q.Upsert("UserKey")
+		q.AddValue("UserKey", 5)
+		q.AddValue("UserName", "eaglebush")
+
+		s, v, err := q.Build()
+		if err != nil {
+			t.Fatalf("Error: %s", err)
+		}
+		if s != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.dialect, s, tt.want)
+		}
+		if len(v) != 2 {
+			t.Errorf("%s: unexpected args: %v", tt.dialect, v)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Package urlfilter maps the query parameters of REST list endpoints onto a query builder:
+//
+//	?status=active&age__gte=18&sort=-created_at&page=2&size=50
+//
+// A parameter filters a field with an operator suffix: eq (the default), ne, gt, gte, lt, lte, like, ilike,
+// in with comma separated values, and isnull with true or false. The sort parameter lists the sort fields
+// separated by commas, descending when prefixed with -. The page and size parameters paginate the query.
+// Only the fields of the endpoint's Spec are accepted.
+package urlfilter
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	qb "github.com/eaglebush/querybuilder/v2"
+)
+
+// errors
+var (
+	ErrNotAllowed   = errors.New("query parameter is not allowed")
+	ErrInvalidValue = errors.New("invalid query parameter value")
+)
+
+// Field is a filter field of an endpoint
+type Field struct {
+	Column    string        // Column of the field. When empty, the field name is the column
+	Operators []qb.Operator // Operators allowed on the field. When nil, all operators are allowed
+}
+
+// Spec is the allowlist of an endpoint
+type Spec struct {
+	Filters     map[string]Field  // Filter fields by parameter name
+	Sorts       map[string]string // Sort columns by sort field name
+	PageSize    int               // Page size when the size parameter is missing. Zero disables pagination without it
+	MaxPageSize int               // Largest page size accepted. Zero accepts any size
+	Ignore      bool              // When true, parameters outside of the allowlist are ignored instead of rejected
+}
+
+// reserved parameter names
+const (
+	sortParam = "sort"
+	pageParam = "page"
+	sizeParam = "size"
+)
+
+var suffixes = map[string]qb.Operator{
+	"eq":    qb.OpEq,
+	"ne":    qb.OpNe,
+	"gt":    qb.OpGt,
+	"gte":   qb.OpGte,
+	"lt":    qb.OpLt,
+	"lte":   qb.OpLte,
+	"like":  qb.OpLike,
+	"ilike": qb.OpILike,
+	"in":    qb.OpIn,
+}
+
+// Apply adds the filters, the order and the pagination of the query parameters to the builder.
+// The filters are added in the sorted order of the parameter names so that the generated SQL is stable.
+func Apply(q *qb.QueryBuilder, values url.Values, spec Spec) error {
+	names := make([]string, 0, len(values))
+	for k := range values {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		switch name {
+		case sortParam, pageParam, sizeParam:
+			continue
+		}
+		for _, v := range values[name] {
+			c, err := spec.cond(name, v)
+			if err != nil {
+				if errors.Is(err, ErrNotAllowed) && spec.Ignore {
+					break
+				}
+				return err
+			}
+			q.Where(c)
+		}
+	}
+	if err := spec.order(q, values.Get(sortParam)); err != nil {
+		return err
+	}
+	return spec.paginate(q, values)
+}
+
+// cond returns the condition of a filter parameter
+func (s Spec) cond(name, value string) (qb.Cond, error) {
+	field, suffix := name, "eq"
+	if i := strings.LastIndex(name, "__"); i > 0 {
+		field, suffix = name[:i], name[i+2:]
+	}
+	f, ok := s.Filters[field]
+	if !ok {
+		return qb.Cond{}, fmt.Errorf("%w: %s", ErrNotAllowed, name)
+	}
+	col := f.Column
+	if col == "" {
+		col = field
+	}
+	if suffix == "isnull" {
+		isnull, err := strconv.ParseBool(value)
+		if err != nil {
+			return qb.Cond{}, fmt.Errorf("%w: %s=%s", ErrInvalidValue, name, value)
+		}
+		if isnull {
+			return qb.IsNull(col), f.allow(name, qb.OpEq)
+		}
+		return qb.NotNull(col), f.allow(name, qb.OpNe)
+	}
+	op, ok := suffixes[suffix]
+	if !ok {
+		return qb.Cond{}, fmt.Errorf("%w: %s", ErrNotAllowed, name)
+	}
+	if op == qb.OpIn {
+		parts := strings.Split(value, ",")
+		in := make([]interface{}, len(parts))
+		for i, p := range parts {
+			in[i] = p
+		}
+		return qb.In(col, in...), f.allow(name, op)
+	}
+	return qb.Cond{Column: col, Operator: op, Value: value}, f.allow(name, op)
+}
+
+// allow returns an error when the operator is not allowed on the field
+func (f Field) allow(name string, op qb.Operator) error {
+	if f.Operators == nil {
+		return nil
+	}
+	for _, o := range f.Operators {
+		if o == op {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrNotAllowed, name)
+}
+
+// order adds the sort fields to the builder
+func (s Spec) order(q *qb.QueryBuilder, sorts string) error {
+	if sorts == "" {
+		return nil
+	}
+	for _, f := range strings.Split(sorts, ",") {
+		dir := qb.ASC
+		if strings.HasPrefix(f, "-") {
+			f, dir = f[1:], qb.DESC
+		}
+		col, ok := s.Sorts[f]
+		if !ok {
+			if s.Ignore {
+				continue
+			}
+			return fmt.Errorf("%w: sort %s", ErrNotAllowed, f)
+		}
+		q.AddOrder(col, dir)
+	}
+	return nil
+}
+
+// paginate applies the page and size parameters to the builder
+func (s Spec) paginate(q *qb.QueryBuilder, values url.Values) error {
+	page, size := 1, s.PageSize
+	for _, p := range []struct {
+		name string
+		dest *int
+	}{{pageParam, &page}, {sizeParam, &size}} {
+		v := values.Get(p.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return fmt.Errorf("%w: %s=%s", ErrInvalidValue, p.name, v)
+		}
+		*p.dest = n
+	}
+	if s.MaxPageSize > 0 && size > s.MaxPageSize {
+		return fmt.Errorf("%w: size=%d exceeds %d", ErrInvalidValue, size, s.MaxPageSize)
+	}
+	if size > 0 {
+		q.Paginate(page, size)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package urlfilter
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	qb "github.com/eaglebush/querybuilder/v2"
+)
+
+var users = Spec{
+	Filters: map[string]Field{
+		"status": {Column: "Status"},
+		"age":    {Column: "Age", Operators: []qb.Operator{qb.OpGte, qb.OpLte}},
+	},
+	Sorts:       map[string]string{"created_at": "CreatedAt"},
+	PageSize:    20,
+	MaxPageSize: 100,
+}
+
+func TestApply(t *testing.T) {
+	v, _ := url.ParseQuery("status__in=A,B&age__gte=18&sort=-created_at&page=2")
+	q := qb.New(qb.WithTableName("Users"), qb.WithDialect(qb.POSTGRES))
+	q.AddColumn("UserName")
+	if err := Apply(q, v, users); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	s, args, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT UserName FROM Users WHERE Age >= $1 AND Status IN ($2, $3) ORDER BY CreatedAt DESC LIMIT 20 OFFSET 20;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"18", "A", "B"}) {
+		t.Errorf("unexpected args: %v", args)
+	}
+
+	for _, query := range []string{"age=18", "email=a", "sort=password", "size=500", "page=x"} {
+		v, _ := url.ParseQuery(query)
+		err := Apply(qb.New(qb.WithTableName("Users")), v, users)
+		if !errors.Is(err, ErrNotAllowed) && !errors.Is(err, ErrInvalidValue) {
+			t.Errorf("%s: unexpected error %v", query, err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package querybuilder
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// uuidFunctions are the functions that generate a UUID on the server
+var uuidFunctions = map[Dialect]string{
+	POSTGRES:  "gen_random_uuid()",
+	SQLSERVER: "NEWID()",
+	MYSQL:     "UUID()",
+	SNOWFLAKE: "UUID_STRING()",
+	BIGQUERY:  "GENERATE_UUID()",
+	DUCKDB:    "gen_random_uuid()",
+}
+
+// DefaultUUID replaces a nil value with a new UUID. It renders the UUID function of the dialect:
+// gen_random_uuid() on PostgreSQL and DuckDB, NEWID() on SQL Server, UUID() on MySQL, UUID_STRING() on Snowflake
+// and GENERATE_UUID() on BigQuery. Other dialects bind a random version 4 UUID generated by the client.
+func DefaultUUID() ValueOption {
+	return func(vco *ValueCompareOption) error {
+		vco.DefaultUUID = true
+		return nil
+	}
+}
+
+// newUUID returns the UUID function of the dialect, or a client-side UUID to bind as a parameter
+func (qb *QueryBuilder) newUUID() (value interface{}, sqlstring bool) {
+	if fn, ok := uuidFunctions[qb.Dialect]; ok {
+		return fn, false
+	}
+	return newUUIDv4(), true
+}
+
+// newUUIDv4 returns a random version 4 UUID in its canonical form
+func newUUIDv4() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
@@ -0,0 +1,58 @@
+package querybuilder
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestDefaultUUID(t *testing.T) {
+	q := New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(POSTGRES))
+	q.AddValue("OrderID", nil, DefaultUUID())
+	q.AddValue("Customer", "ACME")
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (OrderID, Customer) VALUES (gen_random_uuid(),$1);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if len(v) != 1 {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	q = New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(SQLITE))
+	q.AddValue("OrderID", nil, DefaultUUID())
+	if s, v, err = q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (OrderID) VALUES (?);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	if len(v) != 1 || !re.MatchString(v[0].(string)) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	// a supplied value is kept
+	q = New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(SQLSERVER))
+	q.AddValue("OrderID", "6f1c", DefaultUUID())
+	if _, v, _ = q.Build(); len(v) != 1 || v[0] != "6f1c" {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
+
+func TestDefaultUUIDDenyRaw(t *testing.T) {
+	q := New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(POSTGRES), WithSettings(Settings{DenyRaw: true}))
+	q.AddValue("OrderID", nil, DefaultUUID())
+	if _, _, err := q.Build(); err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+
+	// a raw value supplied by the caller is still denied
+	q = New(WithTableName("Orders"), WithCommand(INSERT), WithDialect(POSTGRES), WithSettings(Settings{DenyRaw: true}))
+	q.AddValue("OrderID", "1); DROP TABLE x; --", IsSqlString(false), DefaultUUID())
+	if _, _, err := q.Build(); !errors.Is(err, ErrRawDenied) {
+		t.Errorf("got %v, want %v", err, ErrRawDenied)
+	}
+}
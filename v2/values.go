@@ -0,0 +1,81 @@
+package querybuilder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SourceValues sets the source of a SELECT command to a VALUES list, such as
+// (VALUES (?, ?), (?, ?)) AS v(OrderKey, Qty), for joining lists of values against tables.
+// The values are bound as parameters, and missing or nil values are written as NULL.
+// It is supported on PostgreSQL, SQL Server and DuckDB.
+func (qb *QueryBuilder) SourceValues(rows [][]interface{}, alias string, columns ...string) *QueryBuilder {
+	qb.touch()
+	qb.TableName = alias
+	qb.TableAlias = ""
+	qb.tempTable = false
+	qb.sourceRows = rows
+	qb.sourceColumns = columns
+	return qb
+}
+
+// checkSourceValues checks the VALUES source of the builder
+func (qb *QueryBuilder) checkSourceValues() error {
+	if qb.sourceColumns == nil {
+		return nil
+	}
+	switch {
+	case qb.CommandType != SELECT:
+		return fmt.Errorf("%w: VALUES source on %s", ErrNotSupported, qb.CommandType)
+	case qb.Dialect != POSTGRES && qb.Dialect != SQLSERVER && qb.Dialect != DUCKDB:
+		return fmt.Errorf("%w: VALUES source on %s", ErrNotSupported, qb.Dialect)
+	case len(qb.sourceRows) == 0 || len(qb.sourceColumns) == 0:
+		return fmt.Errorf("%w: VALUES source without rows or columns", ErrInvalidOption)
+	}
+	return nil
+}
+
+// sourceValue returns a value of the VALUES source
+func (qb *QueryBuilder) sourceValue(row, col int) interface{} {
+	if col >= len(qb.sourceRows[row]) {
+		return nil
+	}
+	return realValue(qb.sourceRows[row][col])
+}
+
+// valuesSource renders the VALUES source
+func (qb *QueryBuilder) valuesSource(paramcnt, phcnt *int) string {
+	var sb strings.Builder
+	sb.WriteString("(VALUES ")
+	for i := range qb.sourceRows {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("(")
+		for j := range qb.sourceColumns {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			if isNil(qb.sourceValue(i, j)) {
+				sb.WriteString("NULL")
+				continue
+			}
+			sb.WriteString(qb.placeholder(paramcnt))
+			*phcnt++
+		}
+		sb.WriteString(")")
+	}
+	sb.WriteString(") AS " + qb.TableName + "(" + strings.Join(qb.sourceColumns, ", ") + ")")
+	return sb.String()
+}
+
+// sourceArgs appends the values of the VALUES source to the arguments
+func (qb *QueryBuilder) sourceArgs(add func(column string, a interface{})) {
+	for i := range qb.sourceRows {
+		for j, c := range qb.sourceColumns {
+			if v := qb.sourceValue(i, j); !isNil(v) {
+				add(c, v)
+			}
+		}
+	}
+}
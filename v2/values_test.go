@@ -0,0 +1,32 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSourceValues(t *testing.T) {
+	q := New(WithDialect(POSTGRES))
+	q.SourceValues([][]interface{}{{1, 10}, {2, nil}, {3}}, "v", "OrderKey", "Qty")
+	q.AddColumn("v.OrderKey").AddColumn("v.Qty")
+	q.AddFilter("v.OrderKey", 2)
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	want := "SELECT v.OrderKey, v.Qty FROM (VALUES ($1, $2), ($3, NULL), ($4, NULL)) AS v(OrderKey, Qty) WHERE v.OrderKey = $5;"
+	if s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{1, 10, 2, 3, 2}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+
+	q = New(WithDialect(MYSQL))
+	q.SourceValues([][]interface{}{{1}}, "v", "OrderKey")
+	q.AddColumn("OrderKey")
+	if _, _, err = q.Build(); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+}
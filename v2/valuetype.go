@@ -0,0 +1,46 @@
+package querybuilder
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	valueTypesMu sync.RWMutex
+	valueTypes   map[reflect.Type]func(interface{}) interface{}
+)
+
+// RegisterValueType registers the conversion of the values of a domain type, such as money, ULIDs or enums,
+// to the value that is bound. The values of types that are not known to the builder are otherwise read as nil,
+// and are skipped by SkipNilWrite. The conversion applies to pointers to the type as well, and takes precedence
+// over the driver.Valuer of the type. A later registration of the same type replaces the conversion.
+//
+//	RegisterValueType(func(m Money) interface{} { return m.Cents })
+//
+// Register the types at startup, before the builders are built.
+func RegisterValueType[T any](fn func(v T) interface{}) {
+	valueTypesMu.Lock()
+	defer valueTypesMu.Unlock()
+	if valueTypes == nil {
+		valueTypes = make(map[reflect.Type]func(interface{}) interface{})
+	}
+	valueTypes[reflect.TypeOf((*T)(nil)).Elem()] = func(v interface{}) interface{} {
+		return fn(v.(T))
+	}
+}
+
+// registeredValue returns the conversion of a value of a registered type
+func registeredValue(value interface{}) (interface{}, bool) {
+	valueTypesMu.RLock()
+	convert, ok := valueTypes[reflect.TypeOf(value)]
+	valueTypesMu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	ret := convert(value)
+	// the conversion may return a pointer or another type to be read
+	if reflect.TypeOf(ret) != reflect.TypeOf(value) {
+		ret = getv(ret)
+	}
+	return ret, true
+}
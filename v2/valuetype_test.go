@@ -0,0 +1,30 @@
+package querybuilder
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testMoney struct {
+	cents int64
+}
+
+func TestRegisterValueType(t *testing.T) {
+	RegisterValueType(func(m testMoney) interface{} { return m.cents })
+
+	price := testMoney{cents: 250}
+	q := New(WithTableName("Orders"), WithCommand(INSERT), SkipNilWrite(true))
+	q.AddValue("Total", testMoney{cents: 1999})
+	q.AddValue("Price", &price)
+	q.AddValue("Discount", (*testMoney)(nil))
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "INSERT INTO Orders (Total, Price) VALUES (?,?);"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{int64(1999), int64(250)}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}
@@ -0,0 +1,108 @@
+// Package x is a fluent expression language for the filters of a query builder:
+//
+//	q.WhereTree(x.Col("age").Gt(18).And(x.Col("status").In("A", "B")))
+//
+// The expressions compile into condition trees with placeholders for the values.
+package x
+
+import (
+	qb "github.com/eaglebush/querybuilder/v2"
+)
+
+// Column is a column of an expression
+type Column struct {
+	name string
+}
+
+// Expr is a condition expression. It can be passed to WhereTree and combined with other expressions.
+type Expr struct {
+	qb.Tree
+}
+
+// Col returns a column to compare
+func Col(name string) Column {
+	return Column{name: name}
+}
+
+// Eq matches the rows where the column equals the value, or is NULL when the value is nil
+func (c Column) Eq(value interface{}) Expr {
+	return leaf(qb.Eq(c.name, value))
+}
+
+// Ne matches the rows where the column differs from the value, or is not NULL when the value is nil
+func (c Column) Ne(value interface{}) Expr {
+	return leaf(qb.Ne(c.name, value))
+}
+
+// Gt matches the rows where the column is greater than the value
+func (c Column) Gt(value interface{}) Expr {
+	return leaf(qb.Gt(c.name, value))
+}
+
+// Gte matches the rows where the column is greater than or equal to the value
+func (c Column) Gte(value interface{}) Expr {
+	return leaf(qb.Gte(c.name, value))
+}
+
+// Lt matches the rows where the column is less than the value
+func (c Column) Lt(value interface{}) Expr {
+	return leaf(qb.Lt(c.name, value))
+}
+
+// Lte matches the rows where the column is less than or equal to the value
+func (c Column) Lte(value interface{}) Expr {
+	return leaf(qb.Lte(c.name, value))
+}
+
+// Like matches the rows where the column matches the pattern
+func (c Column) Like(pattern interface{}) Expr {
+	return leaf(qb.Like(c.name, pattern))
+}
+
+// ILike matches the rows where the column matches the pattern regardless of case
+func (c Column) ILike(pattern interface{}) Expr {
+	return leaf(qb.ILike(c.name, pattern))
+}
+
+// In matches the rows where the column is one of the values. An empty list matches no rows.
+func (c Column) In(values ...interface{}) Expr {
+	return leaf(qb.In(c.name, values...))
+}
+
+// IsNull matches the rows where the column is NULL
+func (c Column) IsNull() Expr {
+	return leaf(qb.IsNull(c.name))
+}
+
+// NotNull matches the rows where the column is not NULL
+func (c Column) NotNull() Expr {
+	return leaf(qb.NotNull(c.name))
+}
+
+// And joins the expression with others by AND
+func (e Expr) And(others ...Expr) Expr {
+	return Expr{qb.And(nodes(e, others)...)}
+}
+
+// Or joins the expression with others by OR
+func (e Expr) Or(others ...Expr) Expr {
+	return Expr{qb.Or(nodes(e, others)...)}
+}
+
+// Not negates an expression
+func Not(e Expr) Expr {
+	return Expr{qb.Not(e.Tree)}
+}
+
+func leaf(c qb.Cond) Expr {
+	return Expr{qb.Tree{Cond: &c}}
+}
+
+func nodes(e Expr, others []Expr) []qb.Node {
+	n := make([]qb.Node, 0, len(others)+1)
+	n = append(n, e.Tree)
+	for _, o := range others {
+		n = append(n, o.Tree)
+	}
+	return n
+}
@@ -0,0 +1,24 @@
+package x
+
+import (
+	"reflect"
+	"testing"
+
+	qb "github.com/eaglebush/querybuilder/v2"
+)
+
+func TestExpr(t *testing.T) {
+	q := qb.New(qb.WithTableName("Users"), qb.WithDialect(qb.POSTGRES))
+	q.AddColumn("UserName")
+	q.WhereTree(Col("age").Gt(18).And(Col("status").In("A", "B"), Not(Col("email").IsNull())))
+	s, v, err := q.Build()
+	if err != nil {
+		t.Fatalf("Error: %s", err)
+	}
+	if want := "SELECT UserName FROM Users WHERE (age > $1 AND status IN ($2, $3) AND NOT (email IS NULL));"; s != want {
+		t.Errorf("got %q, want %q", s, want)
+	}
+	if !reflect.DeepEqual(v, []interface{}{18, "A", "B"}) {
+		t.Errorf("unexpected args: %v", v)
+	}
+}